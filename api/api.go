@@ -5,23 +5,39 @@ import (
 
 	"github.com/ONSdigital/dp-search-scrubber-api/config"
 	"github.com/ONSdigital/dp-search-scrubber-api/db"
+	"github.com/ONSdigital/dp-search-scrubber-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
 	"github.com/gorilla/mux"
 )
 
 // API provides a struct to wrap the api around
 type API struct {
 	Router *mux.Router
+	// Recognisers find the codes in search queries. More can be registered
+	// before the service starts.
+	Recognisers *Registry
 }
 
 // Setup function sets up the api and returns a pointer to an API struct
 func Setup(ctx context.Context, r *mux.Router, cfg *config.Config) *API {
+	dataBase := db.LoadCsvData(ctx, cfg)
+
 	api := &API{
-		Router: r,
+		Router:      r,
+		Recognisers: DefaultRegistry(dataBase),
 	}
 
-	dataBase := db.LoadCsvData(ctx, cfg)
+	api.Recognisers.Ambiguity = cfg.AmbiguityPolicy
+
+	if !models.ValidAmbiguity(cfg.AmbiguityPolicy) {
+		log.Info(ctx, "Unknown ambiguity policy, using first", log.Data{"ambiguity_policy": cfg.AmbiguityPolicy})
+	}
+
+	limits := models.AreasWithinLimits{MaxBBoxArea: cfg.AreasWithinMaxBBoxArea, MaxRadius: cfg.AreasWithinMaxRadius}
 
-	r.HandleFunc("/scrubber", FindAllMatchingAreasAndIndustriesHandler(dataBase)).Methods("GET").Name("FindAllMatchingAreasAndIndustriesHandler")
+	r.HandleFunc("/scrubber", FindAllMatchingAreasAndIndustriesHandler(dataBase, api.Recognisers)).Methods("GET").Name("FindAllMatchingAreasAndIndustriesHandler")
+	r.HandleFunc("/areas/within", FindAreasWithinHandler(dataBase, limits)).Methods("GET").Name("FindAreasWithinHandler")
+	r.HandleFunc("/areas/locate", FindAreaAtLocationHandler(dataBase)).Methods("GET").Name("FindAreaAtLocationHandler")
 
 	return api
 }
@@ -28,4 +28,12 @@ func TestSetup(t *testing.T) {
 	// Assert that the "/scrubber" route was added
 	route := r.Get("FindAllMatchingAreasAndIndustriesHandler")
 	assert.NotNil(t, route, "Expected FindAllMatchingAreasAndIndustriesHandler to be added")
+
+	// Assert that the "/areas/within" route was added
+	route = r.Get("FindAreasWithinHandler")
+	assert.NotNil(t, route, "Expected FindAreasWithinHandler to be added")
+
+	// Assert that the "/areas/locate" route was added
+	route = r.Get("FindAreaAtLocationHandler")
+	assert.NotNil(t, route, "Expected FindAreaAtLocationHandler to be added")
 }
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/db"
+	"github.com/ONSdigital/dp-search-scrubber-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// FindAreasWithinHandler returns the output areas whose centroids are within
+// a bounding box or radius, no larger than limits
+func FindAreasWithinHandler(scrubberDB db.ScrubberDB, limits models.AreasWithinLimits) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		ctx := r.Context()
+
+		start := time.Now()
+
+		if scrubberDB.Locations.Len() == 0 {
+			log.Error(ctx, "There is no location data to search", fmt.Errorf("missing centroid data"))
+
+			w.Header().Set("X-Error-Message", "There was an issue with the database")
+
+			writeErrorResp(ctx, w, http.StatusInternalServerError)
+
+			return
+		}
+
+		params, err := models.GetAreasWithinParams(r.URL.Query(), limits)
+		if err != nil {
+			log.Error(ctx, "Error getting areas within query", err)
+
+			writeErrorResp(ctx, w, http.StatusBadRequest)
+
+			return
+		}
+
+		lang, err := models.GetLanguage(r.URL.Query(), r.Header.Get("Accept-Language"))
+		if err != nil {
+			log.Error(ctx, "Error getting areas within language", err)
+
+			writeErrorResp(ctx, w, http.StatusBadRequest)
+
+			return
+		}
+
+		w.Header().Set("Content-Language", lang)
+
+		areasResp := models.AreasResp{
+			Areas: groupAreas(getAreasWithin(params, scrubberDB), params.GroupBy, lang),
+		}
+
+		// an empty list rather than null when nothing is found
+		if areasResp.Areas == nil {
+			areasResp.Areas = []models.AreaResp{}
+		}
+
+		areasResp.Time = fmt.Sprint(time.Since(start).Microseconds(), "µs")
+
+		if err := json.NewEncoder(w).Encode(areasResp); err != nil {
+			log.Error(ctx, "Unable to encode the response data", err)
+
+			writeErrorResp(ctx, w, http.StatusInternalServerError)
+		}
+	}
+}
+
+func getAreasWithin(params *models.AreasWithinParams, scrubberDB db.ScrubberDB) []*db.Area {
+	if params.BBox != nil {
+		return scrubberDB.Locations.WithinBox(params.BBox.Min.Latitude, params.BBox.Min.Longitude, params.BBox.Max.Latitude, params.BBox.Max.Longitude)
+	}
+
+	return scrubberDB.Locations.WithinRadius(params.Centre.Latitude, params.Centre.Longitude, params.Radius)
+}
+
+// FindAreaAtLocationHandler returns the output area containing a point, with
+// its local authority and region
+func FindAreaAtLocationHandler(scrubberDB db.ScrubberDB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		ctx := r.Context()
+
+		start := time.Now()
+
+		if scrubberDB.Boundaries.Len() == 0 && scrubberDB.Locations.Len() == 0 {
+			log.Error(ctx, "There is no location data to search", fmt.Errorf("missing boundary and centroid data"))
+
+			w.Header().Set("X-Error-Message", "There was an issue with the database")
+
+			writeErrorResp(ctx, w, http.StatusInternalServerError)
+
+			return
+		}
+
+		point, err := models.GetLocateParams(r.URL.Query())
+		if err != nil {
+			log.Error(ctx, "Error getting locate query", err)
+
+			writeErrorResp(ctx, w, http.StatusBadRequest)
+
+			return
+		}
+
+		lang, err := models.GetLanguage(r.URL.Query(), r.Header.Get("Accept-Language"))
+		if err != nil {
+			log.Error(ctx, "Error getting locate language", err)
+
+			writeErrorResp(ctx, w, http.StatusBadRequest)
+
+			return
+		}
+
+		w.Header().Set("Content-Language", lang)
+
+		areasResp := models.AreasResp{
+			Areas: []models.AreaResp{},
+		}
+
+		if area := scrubberDB.Locate(point.Latitude, point.Longitude); area != nil {
+			areasResp.Areas = groupAreas([]*db.Area{area}, models.GroupByNone, lang)
+		}
+
+		areasResp.Time = fmt.Sprint(time.Since(start).Microseconds(), "µs")
+
+		if err := json.NewEncoder(w).Encode(areasResp); err != nil {
+			log.Error(ctx, "Unable to encode the response data", err)
+
+			writeErrorResp(ctx, w, http.StatusInternalServerError)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/api/mock"
+	"github.com/ONSdigital/dp-search-scrubber-api/db"
+	"github.com/ONSdigital/dp-search-scrubber-api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindAreasWithinHandler(t *testing.T) {
+	handler := FindAreasWithinHandler(mock.DB(), models.AreasWithinLimits{})
+
+	tests := []struct {
+		name          string
+		url           string
+		expectedCodes []string
+	}{
+		{
+			name:          "bounding box around London",
+			url:           "/areas/within?bbox=-0.2,51.4,0,51.6&group_by=none",
+			expectedCodes: []string{"OAC1", "OAC4"},
+		},
+		{
+			name:          "radius around Manchester",
+			url:           "/areas/within?lat=53.4&lon=-2.2&radius=10000&group_by=none",
+			expectedCodes: []string{"OAC2"},
+		},
+		{
+			name:          "radius with nothing in it",
+			url:           "/areas/within?lat=50&lon=-5&radius=1000",
+			expectedCodes: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler(w, httptest.NewRequest(http.MethodGet, tt.url, http.NoBody))
+
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			var resp models.AreasResp
+			assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &resp))
+
+			codes := []string{}
+			for _, area := range resp.Areas {
+				codes = append(codes, area.Code)
+			}
+
+			assert.ElementsMatch(t, tt.expectedCodes, codes)
+		})
+	}
+}
+
+func TestFindAreasWithinHandlerErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		expectedStatus int
+	}{
+		{
+			name:           "missing parameters",
+			url:            "/areas/within",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid bounding box",
+			url:            "/areas/within?bbox=1,2,3",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "bounding box over the maximum area",
+			url:            "/areas/within?bbox=-0.2,51.4,0,51.6",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "radius over the maximum",
+			url:            "/areas/within?lat=51.51&lon=-0.09&radius=5000",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	limits := models.AreasWithinLimits{MaxBBoxArea: 100, MaxRadius: 1000}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			FindAreasWithinHandler(mock.DB(), limits)(w, httptest.NewRequest(http.MethodGet, tt.url, http.NoBody))
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+
+	t.Run("no location data", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		FindAreasWithinHandler(mock.EmptyDB(), models.AreasWithinLimits{})(w, httptest.NewRequest(http.MethodGet, "/areas/within?bbox=-0.2,51.4,0,51.6", http.NoBody))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestFindAreaAtLocationHandler(t *testing.T) {
+	handler := FindAreaAtLocationHandler(mock.DB())
+
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/areas/locate?lat=51.511&lon=-0.099", http.NoBody))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp models.AreasResp
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &resp))
+
+	if assert.Len(t, resp.Areas, 1) {
+		assert.Equal(t, "OAC4", resp.Areas[0].Code)
+		assert.Equal(t, db.LevelOutputArea, resp.Areas[0].Level)
+		assert.Equal(t, "RC1", resp.Areas[0].RegionCode)
+	}
+
+	t.Run("invalid point", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(http.MethodGet, "/areas/locate?lat=51.511", http.NoBody))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("in Welsh", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/areas/locate?lat=52.48&lon=-1.89", http.NoBody)
+		r.Header.Set("Accept-Language", "cy-GB,cy;q=0.9,en;q=0.8")
+		handler(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "cy", w.Header().Get("Content-Language"))
+
+		var resp models.AreasResp
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &resp))
+
+		if assert.Len(t, resp.Areas, 1) {
+			assert.Equal(t, "LAN3CY", resp.Areas[0].Name)
+			assert.Equal(t, "RN3CY", resp.Areas[0].Region)
+		}
+	})
+
+	t.Run("invalid lang", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(http.MethodGet, "/areas/locate?lat=51.511&lon=-0.099&lang=fr", http.NoBody))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("no location data", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		FindAreaAtLocationHandler(mock.EmptyDB())(w, httptest.NewRequest(http.MethodGet, "/areas/locate?lat=51.511&lon=-0.099", http.NoBody))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
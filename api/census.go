@@ -0,0 +1,85 @@
+package api
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/db"
+	"github.com/ONSdigital/dp-search-scrubber-api/models"
+)
+
+// codeCensus is the name of the kind of code for Census 2021 tables and
+// variables
+const codeCensus = "census"
+
+// censusVariableRe matches words joined by underscores, as Census 2021
+// variables such as hh_size are written, which would otherwise be split apart
+// with the rest of the punctuation
+var censusVariableRe = regexp.MustCompile(`\b[A-Za-z0-9]+(?:_[A-Za-z0-9]+)+\b`)
+
+// censusRecogniser finds the Census 2021 tables and variables in a query that
+// are in the list of Census codes. Tables such as TS001 are matched in any
+// case. A variable is matched when written with its underscores, such as
+// hh_size, or with a digit, so that variables named like words such as "sex"
+// are left to the full text search.
+type censusRecogniser struct {
+	codes *db.Index[db.CensusCode]
+}
+
+func (censusRecogniser) Name() string {
+	return codeCensus
+}
+
+func (censusRecogniser) Section() string {
+	return "census"
+}
+
+func (r censusRecogniser) Match(token string) (string, bool) {
+	for _, code := range r.codes.Get(strings.ToLower(token)) {
+		if code.Type() == db.CensusTable || strings.ContainsFunc(token, unicode.IsDigit) {
+			return code.Code, true
+		}
+	}
+
+	return "", false
+}
+
+// Score is high, as only codes in the list of Census codes are matched
+func (censusRecogniser) Score(string) float64 {
+	return 0.9
+}
+
+// MatchSpans takes the variables written with underscores out of query
+func (r censusRecogniser) MatchSpans(query string) (codes []string, rest string) {
+	rest = censusVariableRe.ReplaceAllStringFunc(query, func(match string) string {
+		found := r.codes.Get(strings.ToLower(match))
+		if len(found) == 0 {
+			return match
+		}
+
+		codes = append(codes, found[0].Code)
+
+		return " "
+	})
+
+	return codes, rest
+}
+
+func (r censusRecogniser) Resolve(codes []string, _ *Request, results *models.Results) {
+	var census []models.CensusResp
+
+	for _, c := range codes {
+		for _, code := range r.codes.Get(strings.ToLower(c)) {
+			census = append(census, models.CensusResp{
+				Code:  code.Code,
+				Title: code.Title,
+				Type:  code.Type(),
+			})
+		}
+	}
+
+	if len(census) > 0 {
+		results.Add(r.Section(), census)
+	}
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/api/mock"
+	"github.com/ONSdigital/dp-search-scrubber-api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCensusRecogniserMatch(t *testing.T) {
+	recogniser := censusRecogniser{codes: mock.DB().Census}
+
+	tests := []struct {
+		token    string
+		expected string
+	}{
+		{token: "TS001", expected: "TS001"},
+		{token: "rm052", expected: "RM052"},
+		{token: "sex"},
+		{token: "TS002"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			code, ok := recogniser.Match(tt.token)
+			assert.Equal(t, tt.expected != "", ok)
+			assert.Equal(t, tt.expected, code)
+		})
+	}
+
+	codes, rest := recogniser.MatchSpans("households by HH_SIZE, resident_age_6a and hh_tenure")
+	assert.Equal(t, []string{"hh_size", "resident_age_6a"}, codes)
+	assert.Equal(t, "households by  ,   and hh_tenure", rest)
+}
+
+func TestFindAllMatchingAreasAndIndustriesHandlerCensus(t *testing.T) {
+	handler := FindAllMatchingAreasAndIndustriesHandler(mock.DB(), DefaultRegistry(mock.DB()))
+
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/scrubber?q=TS001+households+hh_size+by+sex", http.NoBody))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp struct {
+		Query   string `json:"query"`
+		Results struct {
+			Census []models.CensusResp `json:"census"`
+		} `json:"results"`
+	}
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &resp))
+
+	assert.Equal(t, "households sex", resp.Query)
+	// variables written with underscores are taken out of the query first
+	assert.Equal(t, []models.CensusResp{
+		{Code: "hh_size", Title: "Household size", Type: "variable"},
+		{Code: "TS001", Title: "Number of usual residents in households and communal establishments", Type: "table"},
+	}, resp.Results.Census)
+}
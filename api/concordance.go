@@ -0,0 +1,94 @@
+package api
+
+import (
+	"regexp"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/db"
+	"github.com/ONSdigital/dp-search-scrubber-api/models"
+)
+
+// sic2003CodeRe matches how a SIC 2003 subclass code looks e.g. 52111
+var sic2003CodeRe = regexp.MustCompile(`^\d{5}$`)
+
+// sic2003PrefixedRe matches a SIC 2003 class or subclass code written after
+// "SIC 2003" e.g. SIC 2003 5211 or SIC2003 52111
+var sic2003PrefixedRe = regexp.MustCompile(`(?i)\bsic\s*2003\s*(\d{4,5})\b`)
+
+// sic2003Recogniser finds the SIC 2007 industries that the SIC 2003 codes in
+// a query map to. A five digit number is taken to be a SIC 2003 code only
+// when it is not also a SIC 2007 code, which it is taken to be instead. A code
+// written after "SIC 2003" is always a SIC 2003 code.
+type sic2003Recogniser struct {
+	industries   *db.Index[db.Industry]
+	concordances *db.ConcordanceIndex
+}
+
+func (sic2003Recogniser) Name() string {
+	return models.CrosswalkSIC2003
+}
+
+func (sic2003Recogniser) Section() string {
+	return ""
+}
+
+func (r sic2003Recogniser) Match(token string) (string, bool) {
+	if !sic2003CodeRe.MatchString(token) || len(r.industries.Get(token)) > 0 {
+		return "", false
+	}
+
+	return token, len(r.concordances.ToSIC2007(models.CrosswalkSIC2003, token)) > 0
+}
+
+// Score is high, as only codes in the concordance that are not SIC 2007 codes
+// are matched
+func (sic2003Recogniser) Score(string) float64 {
+	return 0.9
+}
+
+// MatchSpans takes the codes written after "SIC 2003" out of query
+func (r sic2003Recogniser) MatchSpans(query string) (codes []string, rest string) {
+	rest = sic2003PrefixedRe.ReplaceAllStringFunc(query, func(match string) string {
+		code := sic2003PrefixedRe.FindStringSubmatch(match)[1]
+		if len(r.concordances.ToSIC2007(models.CrosswalkSIC2003, code)) == 0 {
+			return match
+		}
+
+		codes = append(codes, code)
+
+		return " "
+	})
+
+	return codes, rest
+}
+
+func (r sic2003Recogniser) Resolve(codes []string, req *Request, results *models.Results) {
+	for _, code := range codes {
+		for _, concordance := range r.concordances.ToSIC2007(models.CrosswalkSIC2003, code) {
+			mappedFrom := &models.ClassificationResp{
+				System: concordance.System,
+				Code:   concordance.Code,
+				Name:   concordance.Name,
+			}
+
+			for _, industry := range getAllMatchingIndustries([]string{concordance.SIC2007}, req.Language, req.DB) {
+				industry.MappedFrom = mappedFrom
+				results.Industries = addIndustries(results.Industries, []models.IndustryResp{industry})
+			}
+		}
+	}
+}
+
+// addCrosswalks gives each of industries its equivalent codes in systems
+func addCrosswalks(industries []models.IndustryResp, systems []string, scrubberDB db.ScrubberDB) {
+	for i := range industries {
+		for _, system := range systems {
+			for _, concordance := range scrubberDB.Concordances.Equivalents(industries[i].Code, system) {
+				industries[i].Crosswalk = append(industries[i].Crosswalk, models.ClassificationResp{
+					System: concordance.System,
+					Code:   concordance.Code,
+					Name:   concordance.Name,
+				})
+			}
+		}
+	}
+}
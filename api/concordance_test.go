@@ -0,0 +1,97 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/api/mock"
+	"github.com/ONSdigital/dp-search-scrubber-api/db"
+	"github.com/ONSdigital/dp-search-scrubber-api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSIC2003RecogniserMatch(t *testing.T) {
+	industries := db.NewIndex([]db.Industry{{Code: "01110"}}, func(i db.Industry) string { return i.Code })
+	recogniser := sic2003Recogniser{industries: industries, concordances: mock.DB().Concordances}
+
+	tests := []struct {
+		token    string
+		expected string
+	}{
+		{token: "52111", expected: "52111"},
+		{token: "01110"},
+		{token: "52112"},
+		{token: "5211"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			code, ok := recogniser.Match(tt.token)
+			assert.Equal(t, tt.expected != "", ok)
+
+			if ok {
+				assert.Equal(t, tt.expected, code)
+			}
+		})
+	}
+
+	codes, rest := recogniser.MatchSpans("SIC 2003 01110 and sic2003 52112")
+	assert.Equal(t, []string{"01110"}, codes)
+	assert.Equal(t, "  and sic2003 52112", rest)
+}
+
+func TestSIC2003CodesInQuery(t *testing.T) {
+	mockDB := mock.DB()
+
+	params, err := models.GetScrubberParams(map[string][]string{"q": {"52111 99999"}, "ambiguity": {models.AmbiguityAll}}, "", mockDB.StopWords, DefaultRegistry(mockDB).Matchers())
+	assert.Nil(t, err)
+
+	// 52111 is not the code of an industry, so is only a SIC 2003 code, and
+	// 99999 is taken for a mistyped SIC code as nothing else matches it
+	assert.Empty(t, params.Ambiguities)
+	assert.Equal(t, map[string][]string{
+		models.CrosswalkSIC2003: {"52111"},
+		models.CodeSIC:          {"99999"},
+	}, params.Codes)
+}
+
+func TestSIC2003RecogniserResolve(t *testing.T) {
+	mockDB := mock.DB()
+	recogniser := sic2003Recogniser{industries: mockDB.Industries, concordances: mockDB.Concordances}
+
+	results := models.Results{Industries: []models.IndustryResp{{Code: "IND3", Name: "Industry 3"}}}
+
+	recogniser.Resolve([]string{"52111"}, &Request{DB: mockDB, Language: models.LanguageWelsh}, &results)
+
+	assert.Equal(t, []models.IndustryResp{
+		{Code: "IND3", Name: "Industry 3"},
+		{
+			Code:       "IND2",
+			Name:       "Diwydiant 2",
+			MappedFrom: &models.ClassificationResp{System: "sic2003", Code: "52111", Name: "Retail sale in non-specialised stores"},
+		},
+	}, results.Industries)
+}
+
+func TestAddCrosswalks(t *testing.T) {
+	industries := []models.IndustryResp{
+		{Code: "IND1", Name: "Industry 1"},
+		{Code: "IND3", Name: "Industry 3"},
+	}
+
+	addCrosswalks(industries, []string{models.CrosswalkISIC, models.CrosswalkNACE}, mock.DB())
+
+	assert.Equal(t, []models.IndustryResp{
+		{
+			Code: "IND1",
+			Name: "Industry 1",
+			Crosswalk: []models.ClassificationResp{
+				{System: "isic", Code: "0111", Name: "Growing of cereals"},
+				{System: "nace", Code: "A01.1", Name: "Growing of non-perennial crops"},
+			},
+		},
+		{Code: "IND3", Name: "Industry 3"},
+	}, industries)
+
+	addCrosswalks(industries[1:], []string{models.CrosswalkNACE}, mock.EmptyDB())
+	assert.Empty(t, industries[1].Crosswalk)
+}
@@ -1,5 +1,16 @@
 package api
 
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/ONSdigital/dp-net/v3/request"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+const unexpErrMsg = "An unexpected error occurred while processing your request"
+
 type ErrorResp struct {
 	Errors  []Errors
 	TraceID string
@@ -9,3 +20,36 @@ type Errors struct {
 	ErrorCode string
 	Message   string
 }
+
+// writeErrorResp writes an ErrorResp with the given status code
+func writeErrorResp(ctx context.Context, w http.ResponseWriter, status int) {
+	w.WriteHeader(status)
+
+	errObj := ErrorResp{
+		Errors: []Errors{
+			{
+				ErrorCode: "", // to be added once Nathan finished the error-codes lib
+				Message:   unexpErrMsg,
+			},
+		},
+		TraceID: getRequestID(ctx),
+	}
+
+	if err := json.NewEncoder(w).Encode(errObj); err != nil {
+		log.Error(ctx, "Unable to encode the error response data", err)
+	}
+}
+
+func getRequestID(ctx context.Context) string {
+	requestID := ctx.Value(request.RequestIdKey)
+	if requestID == nil {
+		requestID = ctx.Value("request-id")
+	}
+
+	correlationID, ok := requestID.(string)
+	if !ok {
+		return ""
+	}
+
+	return correlationID
+}
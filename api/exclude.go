@@ -0,0 +1,139 @@
+package api
+
+import (
+	"slices"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/db"
+	"github.com/ONSdigital/dp-search-scrubber-api/models"
+)
+
+// addPhraseMatches adds the areas and industries named by each of phrases as
+// a whole: a local authority or region, an industry synonym, or failing that
+// the industries whose names have every word of the phrase
+func addPhraseMatches(areas []models.AreaResp, industries []models.IndustryResp, phrases []string, lang string, scrubberDB db.ScrubberDB) ([]models.AreaResp, []models.IndustryResp) {
+	for _, phrase := range phrases {
+		foundAreas, foundIndustries := findPhrase(phrase, lang, scrubberDB)
+
+		for _, area := range foundAreas {
+			if !slices.ContainsFunc(areas, func(a models.AreaResp) bool { return a.Level == area.Level && a.Code == area.Code }) {
+				areas = append(areas, area)
+			}
+		}
+
+		industries = addIndustries(industries, foundIndustries)
+	}
+
+	return areas, industries
+}
+
+// findPhrase returns the areas and industries named by phrase as a whole
+func findPhrase(phrase, lang string, scrubberDB db.ScrubberDB) ([]models.AreaResp, []models.IndustryResp) {
+	terms := scrubberDB.StopWords.Terms(phrase)
+
+	var areas []models.AreaResp
+
+	candidates := nameCandidates(scrubberDB.AreaNames.FindWhole(terms))
+
+	for _, name := range candidates {
+		areaResp := areaNameResp(name, lang)
+		areaResp.Ambiguous = len(candidates) > 1
+
+		areas = append(areas, areaResp)
+	}
+
+	if len(areas) > 0 {
+		return areas, nil
+	}
+
+	var industries []models.IndustryResp
+
+	for _, synonym := range scrubberDB.Synonyms.FindWhole(terms) {
+		for _, industry := range scrubberDB.Industries.Get(synonym.Code) {
+			if !slices.ContainsFunc(industries, func(i models.IndustryResp) bool { return i.Code == industry.Code }) {
+				industries = append(industries, models.IndustryResp{Code: industry.Code, Name: industry.NameIn(lang), Synonym: synonym.Term})
+			}
+		}
+	}
+
+	if len(industries) > 0 {
+		return nil, industries
+	}
+
+	for _, name := range scrubberDB.IndustryNames.FindAll(terms) {
+		if !slices.ContainsFunc(industries, func(i models.IndustryResp) bool { return i.Code == name.Industry.Code }) {
+			industries = append(industries, models.IndustryResp{Code: name.Industry.Code, Name: name.Industry.NameIn(lang)})
+		}
+	}
+
+	return nil, industries
+}
+
+// getExclusions returns the words, phrases and codes the query asked to
+// leave out, and the areas and industries they name. Codes are resolved by
+// the recognisers of registry, ungrouped.
+func getExclusions(params *models.ScrubberParams, lang string, scrubberDB db.ScrubberDB, registry *Registry) *models.ExcludedResp {
+	excluded := &models.ExcludedResp{}
+
+	excluded.Terms = append(excluded.Terms, params.Excluded...)
+	excluded.Terms = append(excluded.Terms, registry.Codes(params.ExcludedCodes)...)
+
+	var results models.Results
+
+	registry.Resolve(params.ExcludedCodes, &Request{
+		DB:       scrubberDB,
+		Language: lang,
+		GroupBy:  models.GroupByNone,
+	}, &results)
+
+	excluded.Areas = results.Areas
+	excluded.Industries = results.Industries
+
+	excluded.Areas, excluded.Industries = addPhraseMatches(excluded.Areas, excluded.Industries, params.Excluded, lang, scrubberDB)
+
+	return excluded
+}
+
+// removeExcluded removes the excluded industries from industries, and the
+// excluded areas from areas along with any area inside one of them. Output
+// areas are taken out of the codes of the groups they are in, and a group
+// left with no codes is removed.
+func removeExcluded(areas []models.AreaResp, industries []models.IndustryResp, excluded *models.ExcludedResp) ([]models.AreaResp, []models.IndustryResp) {
+	excludedCodes := make(map[string]bool)
+	for _, area := range excluded.Areas {
+		excludedCodes[area.Code] = true
+	}
+
+	industries = slices.DeleteFunc(industries, func(industry models.IndustryResp) bool {
+		return slices.ContainsFunc(excluded.Industries, func(i models.IndustryResp) bool { return i.Code == industry.Code })
+	})
+
+	if len(excludedCodes) == 0 {
+		return areas, industries
+	}
+
+	areas = slices.DeleteFunc(areas, func(area models.AreaResp) bool {
+		if excludedCodes[area.Code] || slices.ContainsFunc(area.Hierarchy, func(g models.GeographyResp) bool { return excludedCodes[g.Code] }) {
+			return true
+		}
+
+		if len(area.Codes) == 0 {
+			return false
+		}
+
+		for code := range area.Codes {
+			if excludedCodes[code] {
+				delete(area.Codes, code)
+			}
+		}
+
+		return len(area.Codes) == 0
+	})
+
+	for i := range areas {
+		if areas[i].Count > 0 {
+			areas[i].Count = len(areas[i].Codes)
+		}
+	}
+
+	return areas, industries
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/api/mock"
+	"github.com/ONSdigital/dp-search-scrubber-api/db"
+	"github.com/ONSdigital/dp-search-scrubber-api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddPhraseMatches(t *testing.T) {
+	mockDB := mock.DB()
+
+	areas, industries := addPhraseMatches(nil, []models.IndustryResp{{Code: "IND2", Name: "Industry 2"}}, []string{"LAN3", "surgeon", "industry 2", "industry 4"}, models.LanguageEnglish, mockDB)
+
+	if assert.Len(t, areas, 1) {
+		assert.Equal(t, db.LevelLocalAuthority, areas[0].Level)
+		assert.Equal(t, "LAC3", areas[0].Code)
+	}
+
+	// IND2 is already matched, and no industry has every word of "industry 4"
+	assert.Equal(t, []models.IndustryResp{
+		{Code: "IND2", Name: "Industry 2"},
+		{Code: "IND3", Name: "Industry 3", Synonym: "Surgeon"},
+	}, industries)
+}
+
+func TestRemoveExcluded(t *testing.T) {
+	mockDB := mock.DB()
+
+	params := &models.ScrubberParams{
+		Excluded:      []string{"LAN1"},
+		ExcludedCodes: map[string][]string{models.CodeOutputArea: {"OAC2"}, models.CodeSIC: {"IND2"}},
+	}
+
+	excluded := getExclusions(params, models.LanguageEnglish, mockDB, DefaultRegistry(mockDB))
+
+	assert.Equal(t, []string{"LAN1", "IND2", "OAC2"}, excluded.Terms)
+	assert.Equal(t, []models.IndustryResp{{Code: "IND2", Name: "Industry 2"}}, excluded.Industries)
+
+	var excludedAreas []string
+	for _, area := range excluded.Areas {
+		excludedAreas = append(excludedAreas, area.Level+" "+area.Code)
+	}
+
+	assert.Equal(t, []string{"output_area OAC2", "local_authority LAC1"}, excludedAreas)
+
+	industries := []models.IndustryResp{{Code: "IND1"}, {Code: "IND2"}}
+
+	t.Run("grouped by local authority", func(t *testing.T) {
+		areas := getAllMatchingAreas([]string{"OAC1", "OAC2", "OAC3"}, nil, models.GroupByLA, models.LanguageEnglish, mockDB)
+
+		areas, remaining := removeExcluded(areas, industries, excluded)
+
+		if assert.Len(t, areas, 1) {
+			assert.Equal(t, "LAC3", areas[0].Code)
+		}
+
+		assert.Equal(t, []models.IndustryResp{{Code: "IND1"}}, remaining)
+	})
+
+	t.Run("output areas inside an excluded area", func(t *testing.T) {
+		areas := getAllMatchingAreas([]string{"OAC1", "OAC3", "OAC4"}, nil, models.GroupByNone, models.LanguageEnglish, mockDB)
+
+		areas, _ = removeExcluded(areas, nil, excluded)
+
+		if assert.Len(t, areas, 1) {
+			assert.Equal(t, "OAC3", areas[0].Code)
+		}
+	})
+
+	t.Run("excluded output area taken out of a group", func(t *testing.T) {
+		areas := getAllMatchingAreas([]string{"OAC1", "OAC2", "OAC3"}, nil, models.GroupBySupergroup, models.LanguageEnglish, mockDB)
+
+		areas, _ = removeExcluded(areas, nil, &models.ExcludedResp{Areas: []models.AreaResp{{Code: "OAC2"}}})
+
+		if assert.Len(t, areas, 2) {
+			assert.Equal(t, map[string]string{"OAC1": "OAC1"}, areas[0].Codes)
+			assert.Equal(t, 1, areas[0].Count)
+		}
+	})
+}
@@ -0,0 +1,168 @@
+package api
+
+import (
+	"github.com/ONSdigital/dp-search-scrubber-api/db"
+	"github.com/ONSdigital/dp-search-scrubber-api/models"
+)
+
+// areaGrouping describes how output areas are rolled up into a single AreaResp
+type areaGrouping struct {
+	// key returns the group an area belongs to
+	key func(area *db.Area) string
+	// resp returns the response for the group an area belongs to, with its
+	// names in language
+	resp func(area *db.Area, language string) models.AreaResp
+}
+
+var areaGroupings = map[string]areaGrouping{
+	models.GroupByNone: {
+		key: func(area *db.Area) string { return area.OutputAreaCode },
+		resp: func(area *db.Area, language string) models.AreaResp {
+			return models.AreaResp{
+				Level:      db.LevelOutputArea,
+				Code:       area.OutputAreaCode,
+				Name:       area.LANameIn(language),
+				Region:     area.RegionNameIn(language),
+				RegionCode: area.RegionCode,
+			}
+		},
+	},
+	models.GroupByLA: {
+		key: func(area *db.Area) string { return area.LocalAuthorityCode },
+		resp: func(area *db.Area, language string) models.AreaResp {
+			return models.AreaResp{
+				Level:      db.LevelLocalAuthority,
+				Code:       area.LocalAuthorityCode,
+				Name:       area.LANameIn(language),
+				Region:     area.RegionNameIn(language),
+				RegionCode: area.RegionCode,
+			}
+		},
+	},
+	models.GroupByRegion: {
+		key: func(area *db.Area) string { return area.RegionCode },
+		resp: func(area *db.Area, language string) models.AreaResp {
+			return models.AreaResp{
+				Level:      db.LevelRegion,
+				Code:       area.RegionCode,
+				Name:       area.RegionNameIn(language),
+				Region:     area.RegionNameIn(language),
+				RegionCode: area.RegionCode,
+			}
+		},
+	},
+	models.GroupByCountry: {
+		key: func(area *db.Area) string { return area.Country().Code },
+		resp: func(area *db.Area, language string) models.AreaResp {
+			country := area.Country()
+
+			return models.AreaResp{
+				Level: db.LevelCountry,
+				Code:  country.Code,
+				Name:  country.NameIn(language),
+			}
+		},
+	},
+	models.GroupBySupergroup: {
+		key: func(area *db.Area) string { return area.SupergroupCode },
+		resp: func(area *db.Area, language string) models.AreaResp {
+			return models.AreaResp{
+				Level: db.LevelSupergroup,
+				Code:  area.SupergroupCode,
+				Name:  area.SupergroupName,
+			}
+		},
+	},
+}
+
+// groupAreas rolls areas up into one AreaResp per group, in the order each
+// group is first found. groupBy is one of the models.GroupBy values and
+// defaults to grouping by local authority. Names are given in language.
+func groupAreas(areas []*db.Area, groupBy, language string) []models.AreaResp {
+	grouping, ok := areaGroupings[groupBy]
+	if !ok {
+		grouping = areaGroupings[models.GroupByLA]
+	}
+
+	var matchingAreas []models.AreaResp
+
+	groups := make(map[string]int)
+	hierarchies := make(map[string][]db.Geography)
+
+	for _, area := range areas {
+		key := grouping.key(area)
+
+		// the output area itself is not an ancestor of the codes found
+		ancestors := area.Hierarchy()[1:]
+
+		if i, found := groups[key]; found {
+			matchingAreas[i].Codes[area.OutputAreaCode] = area.OutputAreaCode
+			hierarchies[key] = commonAncestors(hierarchies[key], ancestors)
+			continue
+		}
+
+		areaResp := grouping.resp(area, language)
+		areaResp.Codes = map[string]string{
+			area.OutputAreaCode: area.OutputAreaCode,
+		}
+
+		groups[key] = len(matchingAreas)
+		hierarchies[key] = ancestors
+		matchingAreas = append(matchingAreas, areaResp)
+	}
+
+	for key, i := range groups {
+		matchingAreas[i].Hierarchy = geographyResps(hierarchies[key], language)
+		matchingAreas[i].Count = len(matchingAreas[i].Codes)
+
+		// a group spanning more than one country, such as a supergroup, has
+		// no country of its own
+		if country, ok := countryOf(hierarchies[key]); ok {
+			matchingAreas[i].Country = country.NameIn(language)
+			matchingAreas[i].CountryCode = country.Code
+		}
+	}
+
+	return matchingAreas
+}
+
+// commonAncestors returns the part of the chain of ancestors a that is shared
+// with b. Each chain runs from smallest to largest area, so once an ancestor
+// is shared every larger ancestor is shared too.
+func commonAncestors(a, b []db.Geography) []db.Geography {
+	shared := make(map[string]bool, len(b))
+	for _, g := range b {
+		shared[g.Code] = true
+	}
+
+	for i, g := range a {
+		if shared[g.Code] {
+			return a[i:]
+		}
+	}
+
+	return nil
+}
+
+// countryOf returns the country at the top of a chain of ancestors
+func countryOf(ancestors []db.Geography) (db.Geography, bool) {
+	if len(ancestors) == 0 || ancestors[len(ancestors)-1].Level != db.LevelCountry {
+		return db.Geography{}, false
+	}
+
+	return ancestors[len(ancestors)-1], true
+}
+
+func geographyResps(geographies []db.Geography, language string) []models.GeographyResp {
+	resps := make([]models.GeographyResp, 0, len(geographies))
+
+	for _, g := range geographies {
+		resps = append(resps, models.GeographyResp{
+			Level: g.Level,
+			Code:  g.Code,
+			Name:  g.NameIn(language),
+		})
+	}
+
+	return resps
+}
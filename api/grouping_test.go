@@ -0,0 +1,159 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/db"
+	"github.com/ONSdigital/dp-search-scrubber-api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func groupingTestAreas() []*db.Area {
+	return []*db.Area{
+		{OutputAreaCode: "E00000001", LocalAuthorityCode: "E09000001", LAName: "City of London", RegionCode: "E12000007", RegionName: "London", SupergroupCode: "2", SupergroupName: "Cosmopolitans"},
+		{OutputAreaCode: "E00000002", LocalAuthorityCode: "E09000001", LAName: "City of London", RegionCode: "E12000007", RegionName: "London", SupergroupCode: "3", SupergroupName: "Ethnicity Central"},
+		{OutputAreaCode: "E00000003", LocalAuthorityCode: "E09000002", LAName: "Barking and Dagenham", RegionCode: "E12000007", RegionName: "London", SupergroupCode: "3", SupergroupName: "Ethnicity Central"},
+		{OutputAreaCode: "W00000001", LocalAuthorityCode: "W06000001", LAName: "Isle of Anglesey", RegionCode: "W92000004", RegionName: "Wales", SupergroupCode: "1", SupergroupName: "Rural Residents"},
+	}
+}
+
+func TestGroupAreas(t *testing.T) {
+	type group struct {
+		level string
+		code  string
+		name  string
+		count int
+	}
+
+	tests := []struct {
+		name     string
+		groupBy  string
+		expected []group
+	}{
+		{
+			name:    "no grouping",
+			groupBy: models.GroupByNone,
+			expected: []group{
+				{db.LevelOutputArea, "E00000001", "City of London", 1},
+				{db.LevelOutputArea, "E00000002", "City of London", 1},
+				{db.LevelOutputArea, "E00000003", "Barking and Dagenham", 1},
+				{db.LevelOutputArea, "W00000001", "Isle of Anglesey", 1},
+			},
+		},
+		{
+			name:    "grouping by local authority",
+			groupBy: models.GroupByLA,
+			expected: []group{
+				{db.LevelLocalAuthority, "E09000001", "City of London", 2},
+				{db.LevelLocalAuthority, "E09000002", "Barking and Dagenham", 1},
+				{db.LevelLocalAuthority, "W06000001", "Isle of Anglesey", 1},
+			},
+		},
+		{
+			name:    "grouping by local authority when not given",
+			groupBy: "",
+			expected: []group{
+				{db.LevelLocalAuthority, "E09000001", "City of London", 2},
+				{db.LevelLocalAuthority, "E09000002", "Barking and Dagenham", 1},
+				{db.LevelLocalAuthority, "W06000001", "Isle of Anglesey", 1},
+			},
+		},
+		{
+			name:    "grouping by region",
+			groupBy: models.GroupByRegion,
+			expected: []group{
+				{db.LevelRegion, "E12000007", "London", 3},
+				{db.LevelRegion, "W92000004", "Wales", 1},
+			},
+		},
+		{
+			name:    "grouping by country",
+			groupBy: models.GroupByCountry,
+			expected: []group{
+				{db.LevelCountry, "E92000001", "England", 3},
+				{db.LevelCountry, "W92000004", "Wales", 1},
+			},
+		},
+		{
+			name:    "grouping by supergroup",
+			groupBy: models.GroupBySupergroup,
+			expected: []group{
+				{db.LevelSupergroup, "2", "Cosmopolitans", 1},
+				{db.LevelSupergroup, "3", "Ethnicity Central", 2},
+				{db.LevelSupergroup, "1", "Rural Residents", 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groups := groupAreas(groupingTestAreas(), tt.groupBy, models.LanguageEnglish)
+
+			var got []group
+			for _, g := range groups {
+				assert.Len(t, g.Codes, g.Count)
+				got = append(got, group{g.Level, g.Code, g.Name, g.Count})
+			}
+
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestGroupAreasHierarchy(t *testing.T) {
+	groups := groupAreas(groupingTestAreas(), models.GroupByRegion, models.LanguageEnglish)
+
+	// the output areas of London are in different local authorities
+	assert.Equal(t, []models.GeographyResp{
+		{Level: db.LevelRegion, Code: "E12000007", Name: "London"},
+		{Level: db.LevelCountry, Code: "E92000001", Name: "England"},
+	}, groups[0].Hierarchy)
+}
+
+func TestGroupAreasCountry(t *testing.T) {
+	areas := append(groupingTestAreas(),
+		&db.Area{OutputAreaCode: "S00088956", LocalAuthorityCode: "S12000033", LAName: "Aberdeen City", RegionCode: "S92000003", RegionName: "Scotland", SupergroupCode: "1", SupergroupName: "Rural Residents"},
+		&db.Area{OutputAreaCode: "N00000001", LocalAuthorityCode: "N09000003", LAName: "Belfast", RegionCode: "N92000002", RegionName: "Northern Ireland", SupergroupCode: "2", SupergroupName: "Cosmopolitans"},
+	)
+
+	countries := make(map[string]string)
+	for _, g := range groupAreas(areas, models.GroupByLA, models.LanguageEnglish) {
+		countries[g.Code] = g.CountryCode + " " + g.Country
+	}
+
+	assert.Equal(t, map[string]string{
+		"E09000001": "E92000001 England",
+		"E09000002": "E92000001 England",
+		"W06000001": "W92000004 Wales",
+		"S12000033": "S92000003 Scotland",
+		"N09000003": "N92000002 Northern Ireland",
+	}, countries)
+
+	// supergroups 1 and 2 span more than one country
+	countries = make(map[string]string)
+	for _, g := range groupAreas(areas, models.GroupBySupergroup, models.LanguageEnglish) {
+		countries[g.Code] = g.CountryCode
+	}
+
+	assert.Equal(t, map[string]string{"1": "", "2": "", "3": "E92000001"}, countries)
+}
+
+func TestGroupAreasWelsh(t *testing.T) {
+	areas := []*db.Area{
+		{OutputAreaCode: "W00010000", LocalAuthorityCode: "W06000015", LAName: "Cardiff", LAWelshName: "Caerdydd", RegionCode: "W92000004", RegionName: "Wales", RegionWelshName: "Cymru"},
+	}
+
+	groups := groupAreas(areas, models.GroupByLA, models.LanguageWelsh)
+
+	if assert.Len(t, groups, 1) {
+		assert.Equal(t, "Caerdydd", groups[0].Name)
+		assert.Equal(t, "Cymru", groups[0].Region)
+		assert.Equal(t, "Cymru", groups[0].Country)
+		assert.Equal(t, []models.GeographyResp{
+			{Level: db.LevelLocalAuthority, Code: "W06000015", Name: "Caerdydd"},
+			{Level: db.LevelCountry, Code: "W92000004", Name: "Cymru"},
+		}, groups[0].Hierarchy)
+	}
+
+	assert.Equal(t, "Cardiff", groupAreas(areas, models.GroupByLA, models.LanguageEnglish)[0].Name)
+}
@@ -2,13 +2,12 @@ package mock
 
 import (
 	"github.com/ONSdigital/dp-search-scrubber-api/db"
-	"github.com/alediaferia/prefixmap"
 )
 
 func Inds() []db.Industry {
 	industries := []db.Industry{
 		{Code: "IND1", Name: "Industry 1"},
-		{Code: "IND2", Name: "Industry 2"},
+		{Code: "IND2", Name: "Industry 2", WelshName: "Diwydiant 2"},
 		{Code: "IND3", Name: "Industry 3"},
 	}
 
@@ -20,13 +19,25 @@ func Areas() []db.Area {
 		{
 			RegionCode:         "RC1",
 			OutputAreaCode:     "OAC1",
+			Latitude:           51.52,
+			Longitude:          -0.09,
+			SupergroupCode:     "SG1",
+			SupergroupName:     "SGN1",
 			LocalAuthorityCode: "LAC1",
 			LAName:             "LAN1",
 			RegionName:         "RN1",
+			LSOACode:           "LSOA1",
+			LSOAName:           "LSOAN1",
+			MSOACode:           "MSOA1",
+			MSOAName:           "MSOAN1",
 		},
 		{
 			RegionCode:         "RC2",
 			OutputAreaCode:     "OAC2",
+			Latitude:           53.48,
+			Longitude:          -2.24,
+			SupergroupCode:     "SG1",
+			SupergroupName:     "SGN1",
 			LocalAuthorityCode: "LAC2",
 			LAName:             "LAN2",
 			RegionName:         "RN2",
@@ -34,38 +45,140 @@ func Areas() []db.Area {
 		{
 			RegionCode:         "RC3",
 			OutputAreaCode:     "OAC3",
+			Latitude:           52.48,
+			Longitude:          -1.89,
+			SupergroupCode:     "SG2",
+			SupergroupName:     "SGN2",
 			LocalAuthorityCode: "LAC3",
 			LAName:             "LAN3",
+			LAWelshName:        "LAN3CY",
 			RegionName:         "RN3",
+			RegionWelshName:    "RN3CY",
+		},
+		{
+			RegionCode:         "RC1",
+			OutputAreaCode:     "OAC4",
+			Latitude:           51.51,
+			Longitude:          -0.1,
+			SupergroupCode:     "SG2",
+			SupergroupName:     "SGN2",
+			LocalAuthorityCode: "LAC1",
+			LAName:             "LAN1",
+			RegionName:         "RN1",
+			LSOACode:           "LSOA2",
+			LSOAName:           "LSOAN2",
+			MSOACode:           "MSOA1",
+			MSOAName:           "MSOAN1",
 		},
 	}
 
 	return areas
 }
 
-func DB() db.ScrubberDB {
-	areaData := Areas()
-	industryData := Inds()
+func Synonyms() []db.IndustrySynonym {
+	synonyms := []db.IndustrySynonym{
+		{Code: "IND1", Term: "Baker"},
+		{Code: "IND1", Term: "Bakery"},
+		{Code: "IND2", Term: "Dental surgeon"},
+		{Code: "IND3", Term: "Surgeon"},
+		{Code: "IND4", Term: "Unknown industry"},
+	}
 
-	areasMap := prefixmap.New()
-	for _, area := range areaData {
-		areasMap.Insert(area.OutputAreaCode, area)
+	return synonyms
+}
+
+func TimeSeries() []db.TimeSeries {
+	series := []db.TimeSeries{
+		{CDID: "ABMI", Title: "Gross Domestic Product: chained volume measures", Dataset: "PN2"},
+		{CDID: "D7G7", Title: "CPI INDEX 00: ALL ITEMS 2015=100", Dataset: "MM23"},
+		{CDID: "SALE", Title: "Series named like a word", Dataset: "TS1"},
 	}
 
-	industryMap := prefixmap.New()
-	for _, industry := range industryData {
-		industryMap.Insert(industry.Code, industry)
+	return series
+}
+
+func CensusCodes() []db.CensusCode {
+	codes := []db.CensusCode{
+		{Code: "TS001", Title: "Number of usual residents in households and communal establishments"},
+		{Code: "RM052", Title: "Number of bedrooms by household size"},
+		{Code: "hh_size", Title: "Household size"},
+		{Code: "sex", Title: "Sex"},
+		{Code: "resident_age_6a", Title: "Age (6 categories)"},
 	}
 
-	return db.ScrubberDB{
-		AreasPFM:      areasMap,
-		IndustriesPFM: industryMap,
+	return codes
+}
+
+func Occupations() []db.Occupation {
+	occupations := []db.Occupation{
+		{Code: "2", Title: "Professional occupations"},
+		{Code: "22", Title: "Health professionals"},
+		{Code: "225", Title: "Other health professionals"},
+		{Code: "2253", Title: "Dental practitioners"},
+		{Code: "5", Title: "Skilled trades occupations"},
+		{Code: "54", Title: "Textiles, printing and other skilled trades"},
+		{Code: "543", Title: "Food preparation and hospitality trades"},
+		{Code: "5432", Title: "Bakers and flour confectioners"},
+		{Code: "2020", Title: "Unit group numbered like a year"},
 	}
+
+	return occupations
 }
 
-func EmptyDB() db.ScrubberDB {
-	return db.ScrubberDB{
-		AreasPFM:      prefixmap.New(),
-		IndustriesPFM: prefixmap.New(),
+func Concordances() []db.IndustryConcordance {
+	concordances := []db.IndustryConcordance{
+		{SIC2007: "IND1", System: "nace", Code: "A01.1", Name: "Growing of non-perennial crops"},
+		{SIC2007: "IND1", System: "isic", Code: "0111", Name: "Growing of cereals"},
+		{SIC2007: "IND1", System: "sic2003", Code: "01110", Name: "Growing of cereals and other crops"},
+		{SIC2007: "IND2", System: "nace", Code: "G47.1", Name: "Retail sale in non-specialised stores"},
+		{SIC2007: "IND2", System: "sic2003", Code: "52111", Name: "Retail sale in non-specialised stores"},
+		{SIC2007: "IND3", System: "sic2003", Code: "52111", Name: "Retail sale in non-specialised stores"},
 	}
+
+	return concordances
+}
+
+func CodeChanges() []db.CodeChange {
+	changes := []db.CodeChange{
+		{Code: "LAC8", Name: "Old LA 8", Successor: "LAC9", SuccessorName: "Old LA 9"},
+		{Code: "LAC9", Name: "Old LA 9", Successor: "LAC1", SuccessorName: "LAN1"},
+		{Code: "OAC9", Name: "Old OA 9", Successor: "OAC2"},
+		{Code: "OAC9", Name: "Old OA 9", Successor: "OAC3"},
+		{Code: "OAC1", Name: "OA 1", Successor: "OAC5"},
+	}
+
+	return changes
+}
+
+func Places() []db.Place {
+	places := []db.Place{
+		{Code: "BUA1", Name: "Newport", LocalAuthorityCodes: []string{"LAC2"}, OutputAreaCodes: []string{"OAC2"}},
+		{Code: "BUA2", Name: "Newport", LocalAuthorityCodes: []string{"LAC3"}, OutputAreaCodes: []string{"OAC3"}},
+		{Code: "BUA3", Name: "LAN1", LocalAuthorityCodes: []string{"LAC1"}, OutputAreaCodes: []string{"OAC1", "OAC4"}},
+		{Code: "BUA4", Name: "Crawley", LocalAuthorityCodes: []string{"LAC1"}},
+		{Code: "BUA5", Name: "Harrogate", LocalAuthorityCodes: []string{"LAC9"}},
+		{Code: "BUA6", Name: "Bordertown", LocalAuthorityCodes: []string{"LAC1", "LAC2"}, OutputAreaCodes: []string{"OAC1", "OAC2"}},
+	}
+
+	return places
+}
+
+func DB() db.ScrubberDB {
+	areas := Areas()
+
+	sdb := db.NewScrubberDB(areas, Inds(), nil)
+	sdb.AddPlaces(areas, Places())
+	sdb.Synonyms = db.NewSynonymIndex(Synonyms(), sdb.StopWords)
+	sdb.Concordances = db.NewConcordanceIndex(Concordances())
+	sdb.CodeHistory = db.NewCodeHistory(CodeChanges())
+	sdb.TimeSeries = db.NewTimeSeriesIndex(TimeSeries())
+	sdb.Census = db.NewCensusIndex(CensusCodes())
+	sdb.Occupations = db.NewOccupationIndex(Occupations())
+	sdb.OccupationTitles = db.NewOccupationTitleIndex(Occupations(), sdb.StopWords)
+
+	return sdb
+}
+
+func EmptyDB() db.ScrubberDB {
+	return db.NewScrubberDB(nil, nil, nil)
 }
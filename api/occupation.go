@@ -0,0 +1,121 @@
+package api
+
+import (
+	"regexp"
+	"slices"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/db"
+	"github.com/ONSdigital/dp-search-scrubber-api/models"
+)
+
+// codeOccupation is the name of the kind of code for SOC 2020 occupations
+const codeOccupation = "soc"
+
+// socUnitGroupRe matches how a SOC 2020 unit group code looks e.g. 2253
+var socUnitGroupRe = regexp.MustCompile(`^\d{4}$`)
+
+// socPrefixedRe matches "SOC" or "SOC 2020" and the SOC 2020 code of any
+// level written after it, if there is one, e.g. SOC 2253 or SOC2020 225
+var socPrefixedRe = regexp.MustCompile(`(?i)\bsoc(\s*2020\b)?(?:\s*(\d{1,4})\b)?`)
+
+// occupationRecogniser finds the SOC 2020 occupations in a query, by code or
+// by the titles of unit groups. A four digit number is taken to be a unit
+// group when it is one, though one that is also a year from 1900 to 2099 is
+// scored as unlikely, as it is more often a period. A code of any level
+// written after "SOC", such as SOC 2253 or SOC 2020 2253, is always an
+// occupation.
+type occupationRecogniser struct {
+	occupations *db.Index[db.Occupation]
+}
+
+func (occupationRecogniser) Name() string {
+	return codeOccupation
+}
+
+func (occupationRecogniser) Section() string {
+	return "occupations"
+}
+
+func (r occupationRecogniser) Match(token string) (string, bool) {
+	if !socUnitGroupRe.MatchString(token) {
+		return "", false
+	}
+
+	return token, len(r.occupations.Get(token)) > 0
+}
+
+func (occupationRecogniser) Score(token string) float64 {
+	if _, isPeriod := (models.PeriodMatcher{}).Match(token); isPeriod {
+		return 0.2
+	}
+
+	return 0.9
+}
+
+// MatchSpans takes the codes written after "SOC" out of query, along with
+// "SOC 2020" itself, whose year names the classification rather than a period
+func (r occupationRecogniser) MatchSpans(query string) (codes []string, rest string) {
+	rest = socPrefixedRe.ReplaceAllStringFunc(query, func(match string) string {
+		m := socPrefixedRe.FindStringSubmatch(match)
+		year, code := m[1], m[2]
+
+		if code != "" && len(r.occupations.Get(code)) > 0 {
+			codes = append(codes, code)
+			return " "
+		}
+
+		if year != "" {
+			return " " + code
+		}
+
+		return match
+	})
+
+	return codes, rest
+}
+
+func (r occupationRecogniser) Resolve(codes []string, req *Request, results *models.Results) {
+	var occupations []models.OccupationResp
+
+	add := func(occupation db.Occupation) {
+		if !slices.ContainsFunc(occupations, func(o models.OccupationResp) bool { return o.Code == occupation.Code }) {
+			occupations = append(occupations, occupationResp(occupation, req.DB))
+		}
+	}
+
+	for _, code := range codes {
+		for _, occupation := range r.occupations.Get(code) {
+			add(occupation)
+		}
+	}
+
+	for _, match := range req.DB.OccupationTitles.Find(req.Terms) {
+		for _, occupation := range match.Values {
+			add(occupation)
+		}
+	}
+
+	if len(occupations) > 0 {
+		results.Add(r.Section(), occupations)
+	}
+}
+
+// occupationResp returns the response for an occupation, with the groups it
+// belongs to
+func occupationResp(occupation db.Occupation, scrubberDB db.ScrubberDB) models.OccupationResp {
+	resp := models.OccupationResp{
+		Level: occupation.Level(),
+		Code:  occupation.Code,
+		Title: occupation.Title,
+	}
+
+	for _, group := range scrubberDB.OccupationHierarchy(occupation.Code) {
+		resp.Hierarchy = append(resp.Hierarchy, models.OccupationResp{
+			Level: group.Level(),
+			Code:  group.Code,
+			Title: group.Title,
+		})
+	}
+
+	return resp
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/api/mock"
+	"github.com/ONSdigital/dp-search-scrubber-api/models"
+	"github.com/ONSdigital/dp-search-scrubber-api/text"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOccupationRecogniserMatch(t *testing.T) {
+	recogniser := occupationRecogniser{occupations: mock.DB().Occupations}
+
+	tests := []struct {
+		token    string
+		expected string
+	}{
+		{token: "2253", expected: "2253"},
+		{token: "2254"},
+		{token: "225"},
+		{token: "2020", expected: "2020"},
+		{token: "22530"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			code, ok := recogniser.Match(tt.token)
+			assert.Equal(t, tt.expected != "", ok)
+
+			if ok {
+				assert.Equal(t, tt.expected, code)
+			}
+		})
+	}
+
+	assert.Greater(t, recogniser.Score("2253"), recogniser.Score("2020"))
+
+	codes, rest := recogniser.MatchSpans("SOC 2020 2253, soc543 and SOC 2020 2020 not SOC 9999")
+	assert.Equal(t, []string{"2253", "543", "2020"}, codes)
+	assert.Equal(t, " ,   and   not SOC 9999", rest)
+
+	// "SOC 2020" on its own is taken out, so its year is not a period
+	codes, rest = recogniser.MatchSpans("SOC 2020 occupations, soc2020 9999 and SOC")
+	assert.Nil(t, codes)
+	assert.Equal(t, "  occupations,  9999 and SOC", rest)
+}
+
+func TestOccupationRecogniserResolve(t *testing.T) {
+	mockDB := mock.DB()
+	recogniser := occupationRecogniser{occupations: mockDB.Occupations}
+
+	var results models.Results
+
+	recogniser.Resolve([]string{"2253"}, &Request{DB: mockDB, Terms: text.DefaultStopWords().Terms("bakers and flour confectioners, dental practitioners")}, &results)
+
+	assert.Equal(t, []models.OccupationResp{
+		{
+			Level: "unit_group",
+			Code:  "2253",
+			Title: "Dental practitioners",
+			Hierarchy: []models.OccupationResp{
+				{Level: "minor_group", Code: "225", Title: "Other health professionals"},
+				{Level: "sub_major_group", Code: "22", Title: "Health professionals"},
+				{Level: "major_group", Code: "2", Title: "Professional occupations"},
+			},
+		},
+		{
+			Level: "unit_group",
+			Code:  "5432",
+			Title: "Bakers and flour confectioners",
+			Hierarchy: []models.OccupationResp{
+				{Level: "minor_group", Code: "543", Title: "Food preparation and hospitality trades"},
+				{Level: "sub_major_group", Code: "54", Title: "Textiles, printing and other skilled trades"},
+				{Level: "major_group", Code: "5", Title: "Skilled trades occupations"},
+			},
+		},
+	}, results.Entities["occupations"])
+}
+
+func TestOccupationCodesInQuery(t *testing.T) {
+	params, err := models.GetScrubberParams(map[string][]string{"q": {"2253 2021 SOC 2020 543"}}, "", mock.DB().StopWords, DefaultRegistry(mock.DB()).Matchers())
+	assert.Nil(t, err)
+
+	assert.Equal(t, map[string][]string{
+		codeOccupation:    {"543", "2253"},
+		models.CodePeriod: {"2021"},
+	}, params.Codes)
+}
+
+func TestOccupationClassificationInQuery(t *testing.T) {
+	params, err := models.GetScrubberParams(map[string][]string{"q": {"SOC 2020 occupations"}}, "", mock.DB().StopWords, DefaultRegistry(mock.DB()).Matchers())
+	assert.Nil(t, err)
+
+	assert.Equal(t, "occupations", params.Query)
+	assert.Nil(t, params.Codes)
+}
+
+func TestOccupationOrYearInQuery(t *testing.T) {
+	mockDB := mock.DB()
+
+	params, err := models.GetScrubberParams(map[string][]string{"q": {"bakers 2020"}}, "", mockDB.StopWords, DefaultRegistry(mockDB).Matchers())
+	assert.Nil(t, err)
+
+	assert.Empty(t, params.Codes)
+	assert.Equal(t, []models.AmbiguityResp{
+		{
+			Term: "2020",
+			Candidates: []models.CandidateResp{
+				{Kind: models.CodePeriod, Code: "2020", Score: 0.8},
+				{Kind: codeOccupation, Code: "2020", Score: 0.2},
+			},
+		},
+	}, params.Ambiguities)
+
+	params.ResolveAmbiguities(models.AmbiguityFirst)
+	assert.Equal(t, map[string][]string{models.CodePeriod: {"2020"}}, params.Codes)
+	assert.True(t, params.Ambiguities[0].Candidates[0].Selected)
+	assert.False(t, params.Ambiguities[0].Candidates[1].Selected)
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"time"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/models"
+)
+
+// periodRecogniser finds the years, quarters, months and ranges of them in a
+// query, which are returned in the periods section of the results so that a
+// search can filter on release dates
+type periodRecogniser struct {
+	models.PeriodMatcher
+}
+
+func (periodRecogniser) Section() string {
+	return "periods"
+}
+
+func (r periodRecogniser) Resolve(codes []string, _ *Request, results *models.Results) {
+	var periods []models.PeriodResp
+
+	for _, code := range codes {
+		start, end, ok := models.PeriodDates(code)
+		if !ok {
+			continue
+		}
+
+		periods = append(periods, models.PeriodResp{
+			Period: code,
+			Start:  start.Format(time.DateOnly),
+			End:    end.Format(time.DateOnly),
+		})
+	}
+
+	if len(periods) > 0 {
+		results.Add(r.Section(), periods)
+	}
+}
@@ -0,0 +1,25 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeriodRecogniser(t *testing.T) {
+	var results models.Results
+
+	periodRecogniser{}.Resolve([]string{"2022-Q3", "2019/2021"}, &Request{}, &results)
+
+	assert.Equal(t, []models.PeriodResp{
+		{Period: "2022-Q3", Start: "2022-07-01", End: "2022-09-30"},
+		{Period: "2019/2021", Start: "2019-01-01", End: "2021-12-31"},
+	}, results.Entities["periods"])
+
+	var empty models.Results
+
+	periodRecogniser{}.Resolve(nil, &Request{}, &empty)
+
+	assert.Nil(t, empty.Entities)
+}
@@ -0,0 +1,187 @@
+package api
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/db"
+	"github.com/ONSdigital/dp-search-scrubber-api/models"
+)
+
+// Recogniser finds one kind of entity in a search. It matches the tokens of
+// the query that are its codes, and resolves the codes found against the data
+// into results, either into a section of its own with Results.Add or into the
+// areas and industries.
+type Recogniser interface {
+	models.Matcher
+	// Section is the name of the section of the results that Resolve adds
+	// with Results.Add, or empty if it only adds to the areas and
+	// industries
+	Section() string
+	// Resolve adds what codes refer to to results. It is called for every
+	// search, even when no codes were found.
+	Resolve(codes []string, req *Request, results *models.Results)
+}
+
+// Request is the search that recognisers resolve their codes for. Terms are
+// the words of the query for recognisers that also find entities by name.
+type Request struct {
+	DB          db.ScrubberDB
+	Language    string
+	GroupBy     string
+	Coordinates []models.Coordinate
+	Terms       []string
+}
+
+// Registry holds the recognisers used by the search, in the order they are
+// tried on each token of the query
+type Registry struct {
+	recognisers []Recogniser
+	// Ambiguity is the policy for the ambiguous tokens and names of a
+	// search that does not give one, one of the models.Ambiguity values.
+	// models.AmbiguityFirst is used if it is not set.
+	Ambiguity string
+}
+
+// NewRegistry returns a registry of recognisers, whose sections are not
+// checked as those added with Register are
+func NewRegistry(recognisers ...Recogniser) *Registry {
+	return &Registry{recognisers: recognisers}
+}
+
+// DefaultRegistry returns a registry of the recognisers for SIC 2003 codes,
+// SIC codes, output area codes, occupations, periods of time, and the time
+// series CDIDs and Census codes in scrubberDB. SIC 2003 codes and occupations
+// come before periods so that the "2003" of "SIC 2003 52111" and the "2020"
+// of "SOC 2020 2253" are not taken for years.
+func DefaultRegistry(scrubberDB db.ScrubberDB) *Registry {
+	return NewRegistry(
+		sic2003Recogniser{industries: scrubberDB.Industries, concordances: scrubberDB.Concordances},
+		sicRecogniser{industries: scrubberDB.Industries},
+		outputAreaRecogniser{},
+		occupationRecogniser{occupations: scrubberDB.Occupations},
+		periodRecogniser{},
+		timeSeriesRecogniser{series: scrubberDB.TimeSeries},
+		censusRecogniser{codes: scrubberDB.Census},
+	)
+}
+
+// Register adds recogniser to the registry, after the ones already there. It
+// returns an error, and leaves the registry as it was, if the section of the
+// results the recogniser adds is one with its own field, such as "areas", or
+// is already added by another recogniser.
+func (r *Registry) Register(recogniser Recogniser) error {
+	if section := recogniser.Section(); section != "" {
+		if models.IsReservedSection(section) {
+			return fmt.Errorf("recogniser %q: results section %q is reserved", recogniser.Name(), section)
+		}
+
+		for _, other := range r.recognisers {
+			if other.Section() == section {
+				return fmt.Errorf("recogniser %q: results section %q is already added by recogniser %q", recogniser.Name(), section, other.Name())
+			}
+		}
+	}
+
+	r.recognisers = append(r.recognisers, recogniser)
+
+	return nil
+}
+
+// Matchers returns the recognisers as the matchers of the query's codes
+func (r *Registry) Matchers() []models.Matcher {
+	matchers := make([]models.Matcher, 0, len(r.recognisers))
+
+	for _, recogniser := range r.recognisers {
+		matchers = append(matchers, recogniser)
+	}
+
+	return matchers
+}
+
+// Resolve has each recogniser resolve its codes into results
+func (r *Registry) Resolve(codes map[string][]string, req *Request, results *models.Results) {
+	for _, recogniser := range r.recognisers {
+		recogniser.Resolve(codes[recogniser.Name()], req, results)
+	}
+}
+
+// ambiguity returns the policy for ambiguous tokens and names by default
+func (r *Registry) ambiguity() string {
+	if models.ValidAmbiguity(r.Ambiguity) {
+		return r.Ambiguity
+	}
+
+	return models.AmbiguityFirst
+}
+
+// Codes returns codes in the order of the recognisers that found them
+func (r *Registry) Codes(codes map[string][]string) []string {
+	var all []string
+
+	for _, recogniser := range r.recognisers {
+		all = append(all, codes[recogniser.Name()]...)
+	}
+
+	return all
+}
+
+// sicRecogniser finds industries by their SIC codes. Any five digit number
+// is matched, so that a mistyped code is still taken out of the query, but
+// only the codes of industries are valid.
+type sicRecogniser struct {
+	models.SICMatcher
+	industries *db.Index[db.Industry]
+}
+
+func (sicRecogniser) Section() string {
+	return ""
+}
+
+func (r sicRecogniser) Valid(code string) bool {
+	return r.industries == nil || len(r.industries.Get(code)) > 0
+}
+
+func (sicRecogniser) Resolve(codes []string, req *Request, results *models.Results) {
+	results.Industries = addIndustries(results.Industries, getAllMatchingIndustries(codes, req.Language, req.DB))
+}
+
+// outputAreaRecogniser finds output areas by their codes or those of the
+// LSOAs and MSOAs they are in, along with the output areas at the
+// coordinates of the query, and local authorities and regions by their codes.
+// Retired codes are replaced by their successors, and the areas found through
+// them are marked with the codes they were mapped from.
+type outputAreaRecogniser struct {
+	models.OutputAreaMatcher
+}
+
+func (outputAreaRecogniser) Section() string {
+	return ""
+}
+
+func (outputAreaRecogniser) Resolve(codes []string, req *Request, results *models.Results) {
+	if len(codes) == 0 && len(req.Coordinates) == 0 {
+		return
+	}
+
+	codes, mappedFrom := currentCodes(codes, req.DB)
+
+	areas := getAllMatchingAreas(codes, req.Coordinates, req.GroupBy, req.Language, req.DB)
+	areas = append(areas, getAreasByCode(codes, req.Language, req.DB)...)
+
+	markMappedFrom(areas, mappedFrom)
+
+	results.Areas = append(results.Areas, areas...)
+}
+
+// addIndustries adds the industries of found that are not already in
+// industries
+func addIndustries(industries, found []models.IndustryResp) []models.IndustryResp {
+	for _, industry := range found {
+		if !slices.ContainsFunc(industries, func(i models.IndustryResp) bool { return i.Code == industry.Code }) {
+			industries = append(industries, industry)
+		}
+	}
+
+	return industries
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/api/mock"
+	"github.com/ONSdigital/dp-search-scrubber-api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// tagRecogniser recognises made up codes such as ZZ1, which it returns in a
+// section of their own
+type tagRecogniser struct{}
+
+func (tagRecogniser) Name() string {
+	return "tag"
+}
+
+func (tagRecogniser) Match(token string) (string, bool) {
+	return strings.ToUpper(token), strings.HasPrefix(strings.ToUpper(token), "ZZ")
+}
+
+func (tagRecogniser) Section() string {
+	return "tags"
+}
+
+func (r tagRecogniser) Resolve(codes []string, _ *Request, results *models.Results) {
+	if len(codes) > 0 {
+		results.Add(r.Section(), codes)
+	}
+}
+
+// sectionRecogniser adds to the results section it is given
+type sectionRecogniser struct {
+	tagRecogniser
+	section string
+}
+
+func (r sectionRecogniser) Section() string {
+	return r.section
+}
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry(sicRecogniser{}, outputAreaRecogniser{})
+	assert.Nil(t, registry.Register(tagRecogniser{}))
+
+	assert.Equal(t, []string{models.CodeSIC, models.CodeOutputArea, "tag"}, func() []string {
+		var names []string
+		for _, matcher := range registry.Matchers() {
+			names = append(names, matcher.Name())
+		}
+		return names
+	}())
+
+	assert.Equal(t, []string{"12345", "zz1"}, registry.Codes(map[string][]string{"tag": {"zz1"}, models.CodeSIC: {"12345"}}))
+}
+
+func TestRegistryRegisterReservedSection(t *testing.T) {
+	registry := DefaultRegistry(mock.DB())
+	count := len(registry.Matchers())
+
+	for _, section := range []string{"areas", "industries", "countries", "excluded", "ambiguities", "periods"} {
+		t.Run(section, func(t *testing.T) {
+			assert.NotNil(t, registry.Register(sectionRecogniser{section: section}))
+			assert.Len(t, registry.Matchers(), count)
+		})
+	}
+
+	assert.Nil(t, registry.Register(sectionRecogniser{section: "tags"}))
+	assert.Len(t, registry.Matchers(), count+1)
+}
+
+func TestFindAllMatchingAreasAndIndustriesHandlerRecognisers(t *testing.T) {
+	registry := DefaultRegistry(mock.DB())
+	assert.Nil(t, registry.Register(tagRecogniser{}))
+
+	handler := FindAllMatchingAreasAndIndustriesHandler(mock.DB(), registry)
+
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/scrubber?q=zz1+surgeon+zz2+-zz3", http.NoBody))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp models.ScrubberResp
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &resp))
+
+	assert.Equal(t, "surgeon", resp.Query)
+
+	if assert.Len(t, resp.Results.Industries, 1) {
+		assert.Equal(t, "IND3", resp.Results.Industries[0].Code)
+	}
+
+	assert.Equal(t, []string{"ZZ3"}, resp.Results.Excluded.Terms)
+	assert.JSONEq(t, `["ZZ1","ZZ2"]`, string(resp.Results.Entities["tags"].(json.RawMessage)))
+}
@@ -0,0 +1,127 @@
+package api
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/models"
+)
+
+// wordRe finds the words of a query that could be codes
+var wordRe = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+// rewriteLabel is what a code in the query is replaced by
+type rewriteLabel struct {
+	kind   string
+	labels []string
+}
+
+// rewriteQuery replaces the codes in q that areas and industries were found
+// by with their names, returning the rewritten query and the spans of it that
+// were replaced. An output area is named by the group it was rolled up into.
+func rewriteQuery(q string, areas []models.AreaResp, industries []models.IndustryResp) (string, []models.RewriteResp) {
+	labels := make(map[string]*rewriteLabel)
+
+	add := func(code, kind, label string) {
+		code = strings.ToUpper(code)
+		if label == "" || strings.EqualFold(code, label) {
+			return
+		}
+
+		l, ok := labels[code]
+		if !ok {
+			l = &rewriteLabel{kind: kind}
+			labels[code] = l
+		}
+
+		l.labels = appendUnique(l.labels, label)
+	}
+
+	for i := range areas {
+		area := &areas[i]
+
+		add(area.Code, area.Level, area.Name)
+
+		for code := range area.Codes {
+			add(code, area.Level, area.Name)
+		}
+
+		for _, retired := range area.MappedFrom {
+			add(retired.Code, area.Level, area.Name)
+		}
+
+		for _, geography := range area.Hierarchy {
+			add(geography.Code, geography.Level, geography.Name)
+		}
+	}
+
+	for i := range industries {
+		industry := &industries[i]
+		name := lowerFirst(industry.Name)
+
+		add(industry.Code, models.CodeSIC, name)
+
+		if industry.MappedFrom != nil {
+			add(industry.MappedFrom.Code, models.CodeSIC, name)
+		}
+	}
+
+	var (
+		b        strings.Builder
+		rewrites []models.RewriteResp
+		last     int
+		// runes is the length of b in characters, which the spans are
+		// measured in
+		runes int
+	)
+
+	for _, loc := range wordRe.FindAllStringIndex(q, -1) {
+		word := q[loc[0]:loc[1]]
+
+		l, ok := labels[strings.ToUpper(word)]
+		if !ok {
+			continue
+		}
+
+		b.WriteString(q[last:loc[0]])
+		runes += utf8.RuneCountInString(q[last:loc[0]])
+
+		label := strings.Join(l.labels, " or ")
+		start := runes
+		b.WriteString(label)
+		runes += utf8.RuneCountInString(label)
+
+		rewrites = append(rewrites, models.RewriteResp{
+			Start: start,
+			End:   runes,
+			Code:  strings.ToUpper(word),
+			Kind:  l.kind,
+			Label: label,
+		})
+
+		last = loc[1]
+	}
+
+	b.WriteString(q[last:])
+
+	return b.String(), rewrites
+}
+
+// lowerFirst lower-cases the first letter of a name written in sentence
+// case, so that it reads as part of the query, leaving acronyms alone
+func lowerFirst(name string) string {
+	if name == "" {
+		return name
+	}
+
+	first, size := utf8.DecodeRuneInString(name)
+	second, _ := utf8.DecodeRuneInString(name[size:])
+
+	if unicode.IsUpper(second) {
+		return name
+	}
+
+	return string(unicode.ToLower(first)) + name[size:]
+}
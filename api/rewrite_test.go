@@ -0,0 +1,72 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewriteQuery(t *testing.T) {
+	areas := []models.AreaResp{
+		{
+			Level: "local_authority",
+			Code:  "LAC1",
+			Name:  "City of London",
+			Codes: map[string]string{"OAC1": "OAC1"},
+			Hierarchy: []models.GeographyResp{
+				{Level: "lsoa", Code: "LSOAC1", Name: "City of London 001A"},
+				{Level: "local_authority", Code: "LAC1", Name: "City of London"},
+			},
+			MappedFrom: []models.GeographyResp{{Code: "LAC8", Name: "Old LA 8"}},
+		},
+	}
+
+	industries := []models.IndustryResp{
+		{Code: "01140", Name: "Growing of sugar cane"},
+		{Code: "01210", Name: "Growing of grapes", MappedFrom: &models.ClassificationResp{System: models.CrosswalkSIC2003, Code: "0113"}},
+		{Code: "01250", Name: "Growing of other tree and bush fruits", MappedFrom: &models.ClassificationResp{System: models.CrosswalkSIC2003, Code: "0113"}},
+	}
+
+	t.Run("codes are replaced by their names", func(t *testing.T) {
+		rewritten, rewrites := rewriteQuery("dentists in oac1 01140", areas, industries)
+
+		assert.Equal(t, "dentists in City of London growing of sugar cane", rewritten)
+		assert.Equal(t, []models.RewriteResp{
+			{Start: 12, End: 26, Code: "OAC1", Kind: "local_authority", Label: "City of London"},
+			{Start: 27, End: 48, Code: "01140", Kind: models.CodeSIC, Label: "growing of sugar cane"},
+		}, rewrites)
+	})
+
+	t.Run("ancestors, retired codes and codes of more than one industry", func(t *testing.T) {
+		rewritten, rewrites := rewriteQuery("LSOAC1, LAC8 and 0113", areas, industries)
+
+		assert.Equal(t, "City of London 001A, City of London and growing of grapes or growing of other tree and bush fruits", rewritten)
+		if assert.Len(t, rewrites, 3) {
+			assert.Equal(t, models.RewriteResp{Start: 0, End: 19, Code: "LSOAC1", Kind: "lsoa", Label: "City of London 001A"}, rewrites[0])
+			assert.Equal(t, "LAC8", rewrites[1].Code)
+			assert.Equal(t, "0113", rewrites[2].Code)
+			assert.Equal(t, "growing of grapes or growing of other tree and bush fruits", string([]rune(rewritten)[rewrites[2].Start:rewrites[2].End]))
+		}
+	})
+
+	t.Run("offsets count characters", func(t *testing.T) {
+		rewritten, rewrites := rewriteQuery("deintyddion ym Môn OAC1", []models.AreaResp{{Level: "local_authority", Code: "LAC1", Name: "Ynys Môn", Codes: map[string]string{"OAC1": "OAC1"}}}, nil)
+
+		assert.Equal(t, "deintyddion ym Môn Ynys Môn", rewritten)
+		assert.Equal(t, []models.RewriteResp{{Start: 19, End: 27, Code: "OAC1", Kind: "local_authority", Label: "Ynys Môn"}}, rewrites)
+	})
+
+	t.Run("a query without codes is left as it is", func(t *testing.T) {
+		rewritten, rewrites := rewriteQuery("dentists in london -01280", areas, industries)
+
+		assert.Equal(t, "dentists in london -01280", rewritten)
+		assert.Empty(t, rewrites)
+	})
+}
+
+func TestLowerFirst(t *testing.T) {
+	assert.Equal(t, "growing of sugar cane", lowerFirst("Growing of sugar cane"))
+	assert.Equal(t, "NHS activities", lowerFirst("NHS activities"))
+	assert.Equal(t, "", lowerFirst(""))
+}
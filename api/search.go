@@ -1,22 +1,22 @@
 package api
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
-	"github.com/ONSdigital/dp-net/v3/request"
 	"github.com/ONSdigital/dp-search-scrubber-api/db"
 	"github.com/ONSdigital/dp-search-scrubber-api/models"
 	"github.com/ONSdigital/log.go/v2/log"
 )
 
-const unexpErrMsg = "An unexpected error occurred while processing your request"
-
-func FindAllMatchingAreasAndIndustriesHandler(scrubberDB db.ScrubberDB) http.HandlerFunc {
+// FindAllMatchingAreasAndIndustriesHandler returns the areas, industries and
+// other entities found in a query by name or by the codes the recognisers of
+// registry match
+func FindAllMatchingAreasAndIndustriesHandler(scrubberDB db.ScrubberDB, registry *Registry) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 
@@ -24,134 +24,154 @@ func FindAllMatchingAreasAndIndustriesHandler(scrubberDB db.ScrubberDB) http.Han
 
 		start := time.Now()
 
-		if len(scrubberDB.AreasPFM.Children) == 0 && len(scrubberDB.IndustriesPFM.Children) == 0 {
+		if scrubberDB.Areas.Len() == 0 && scrubberDB.Industries.Len() == 0 {
 			log.Error(ctx, "There is no data to display due to a database issue", fmt.Errorf("missing raw data"))
 
 			w.Header().Set("X-Error-Message", "There was an issue with the database")
 
-			w.WriteHeader(http.StatusInternalServerError)
-
-			errObj := ErrorResp{
-				Errors: []Errors{
-					{
-						ErrorCode: "", // to be added once Nathan finished the error-codes lib
-						Message:   unexpErrMsg,
-					},
-				},
-				TraceID: getRequestID(ctx),
-			}
-
-			if err := json.NewEncoder(w).Encode(errObj); err != nil {
-				log.Error(ctx, "Unable to encode the error response data", err)
-			}
+			writeErrorResp(ctx, w, http.StatusInternalServerError)
 
 			return
 		}
 
-		scrubberParams, err := models.GetScrubberParams(r.URL.Query())
+		scrubberParams, err := models.GetScrubberParams(r.URL.Query(), r.Header.Get("Accept-Language"), scrubberDB.StopWords, registry.Matchers())
 		if err != nil {
 			log.Error(ctx, "Error getting scrubber query", err)
 
-			w.WriteHeader(http.StatusBadRequest)
+			writeErrorResp(ctx, w, http.StatusBadRequest)
 
-			errObj := ErrorResp{
-				Errors: []Errors{
-					{
-						ErrorCode: "", // to be added once Nathan finished the error-codes lib
-						Message:   unexpErrMsg,
-					},
-				},
-				TraceID: getRequestID(ctx),
-			}
+			return
+		}
 
-			if err := json.NewEncoder(w).Encode(errObj); err != nil {
-				log.Error(ctx, "Unable to encode the error response data", err)
-			}
+		lang := scrubberParams.Language
+		w.Header().Set("Content-Language", lang)
 
-			return
+		policy := scrubberParams.Ambiguity
+		if policy == "" {
+			policy = registry.ambiguity()
 		}
 
-		matchingAreas := getAllMatchingAreas(scrubberParams.OAC, scrubberDB)
-		matchingIndustries := getAllMatchingIndustries(scrubberParams.SIC, scrubberDB)
+		scrubberParams.ResolveAmbiguities(policy)
+
+		var results models.Results
+
+		registry.Resolve(scrubberParams.Codes, &Request{
+			DB:          scrubberDB,
+			Language:    lang,
+			GroupBy:     scrubberParams.GroupBy,
+			Coordinates: scrubberParams.Coordinates,
+			Terms:       scrubberParams.Terms,
+		}, &results)
+
+		namedAreas, nameAmbiguities, terms := getAreasByName(scrubberParams.Terms, lang, policy, scrubberDB)
+		results.Areas = append(results.Areas, namedAreas...)
+		results.Ambiguities = append(slices.Clone(scrubberParams.Ambiguities), nameAmbiguities...)
+
+		results.Industries, terms = addIndustriesBySynonym(results.Industries, terms, lang, scrubberDB)
+		results.Industries = addIndustriesByName(results.Industries, terms, lang, scrubberDB)
+		results.Areas, results.Industries = addPhraseMatches(results.Areas, results.Industries, scrubberParams.Phrases, lang, scrubberDB)
+
+		excluded := getExclusions(scrubberParams, lang, scrubberDB, registry)
+		results.Areas, results.Industries = removeExcluded(results.Areas, results.Industries, excluded)
+
+		addCrosswalks(results.Industries, scrubberParams.Crosswalk, scrubberDB)
+
+		results.Countries = getCountries(scrubberParams, results.Areas)
+
+		if len(excluded.Terms) > 0 {
+			results.Excluded = excluded
+		}
+
+		rewritten, rewrites := rewriteQuery(r.URL.Query().Get("q"), results.Areas, results.Industries)
 
 		scrubberResp := models.ScrubberResp{
-			Time:  fmt.Sprint(time.Since(start).Microseconds(), "µs"),
-			Query: scrubberParams.Query,
-			Results: models.Results{
-				Areas:      matchingAreas,
-				Industries: matchingIndustries,
-			},
+			Time:           fmt.Sprint(time.Since(start).Microseconds(), "µs"),
+			Query:          scrubberParams.Query,
+			RewrittenQuery: rewritten,
+			Rewrites:       rewrites,
+			Results:        results,
 		}
 
 		if err := json.NewEncoder(w).Encode(scrubberResp); err != nil {
 			log.Error(ctx, "Unable to encode the response data", err)
 
-			w.WriteHeader(http.StatusInternalServerError)
+			writeErrorResp(ctx, w, http.StatusInternalServerError)
+		}
+	}
+}
 
-			errObj := ErrorResp{
-				Errors: []Errors{
-					{
-						ErrorCode: "", // to be added once Nathan finished the error-codes lib
-						Message:   unexpErrMsg,
-					},
-				},
-				TraceID: getRequestID(ctx),
-			}
+func getAllMatchingAreas(querySl []string, coordinates []models.Coordinate, groupBy, lang string, scrubberDB db.ScrubberDB) []models.AreaResp {
+	var areas []*db.Area
 
-			if err := json.NewEncoder(w).Encode(errObj); err != nil {
-				log.Error(ctx, "Unable to encode the error response data", err)
-			}
+	// an output area can be found through more than one of the codes
+	found := make(map[string]bool)
+
+	addArea := func(area *db.Area) {
+		if !found[area.OutputAreaCode] {
+			found[area.OutputAreaCode] = true
+			areas = append(areas, area)
 		}
 	}
+
+	for _, q := range querySl {
+		for _, area := range scrubberDB.AreasByCode(strings.ToUpper(q)) {
+			addArea(area)
+		}
+	}
+
+	for _, c := range coordinates {
+		if area := scrubberDB.Locate(c.Latitude, c.Longitude); area != nil {
+			addArea(area)
+		}
+	}
+
+	return groupAreas(areas, groupBy, lang)
 }
 
-func getAllMatchingAreas(querySl []string, scrubberDB db.ScrubberDB) []models.AreaResp {
-	var matchingAreas []models.AreaResp
+// getCountries returns the countries the query refers to, whether by name or
+// through the areas it matched once exclusions are removed, named in the
+// language of the query. A code that matched no area names no country.
+func getCountries(params *models.ScrubberParams, areas []models.AreaResp) []models.GeographyResp {
+	found := make(map[string]bool)
 
-	areaRespMap := make(map[string]models.AreaResp)
+	for _, code := range params.Countries {
+		found[code] = true
+	}
 
-	for _, q := range querySl {
-		matchingRecords := scrubberDB.AreasPFM.Get(strings.ToUpper(q))
-		for _, rData := range matchingRecords {
-			area := rData.(db.Area)
-			key := area.LAName + area.RegionName + area.RegionCode
-
-			if _, found := areaRespMap[key]; found {
-				areaRespMap[key].Codes[area.OutputAreaCode] = area.OutputAreaCode
-			} else {
-				areaResp := models.AreaResp{
-					Name:       area.LAName,
-					Region:     area.RegionName,
-					RegionCode: area.RegionCode,
-					Codes: map[string]string{
-						area.OutputAreaCode: area.OutputAreaCode,
-					},
-				}
+	for _, area := range areas {
+		found[area.CountryCode] = true
 
-				areaRespMap[key] = areaResp
-				matchingAreas = append(matchingAreas, areaRespMap[key])
-			}
+		for code := range area.Codes {
+			found[db.CountryOfCode(code).Code] = true
 		}
 	}
 
-	return matchingAreas
+	var countries []models.GeographyResp
+
+	for _, country := range db.Countries() {
+		if found[country.Code] {
+			countries = append(countries, models.GeographyResp{
+				Level: country.Level,
+				Code:  country.Code,
+				Name:  country.NameIn(params.Language),
+			})
+		}
+	}
+
+	return countries
 }
 
-func getAllMatchingIndustries(querySl []string, scrubberDB db.ScrubberDB) []models.IndustryResp {
+func getAllMatchingIndustries(querySl []string, lang string, scrubberDB db.ScrubberDB) []models.IndustryResp {
 	var matchingIndustries []models.IndustryResp
 
 	validation := make(map[string]string)
 
 	for _, q := range querySl {
-		matchingRecords := scrubberDB.IndustriesPFM.Get(strings.ToUpper(q))
-
-		for _, rData := range matchingRecords {
-			industry := rData.(db.Industry)
-
+		for _, industry := range scrubberDB.Industries.Get(strings.ToUpper(q)) {
 			if _, valid := validation[industry.Code]; !valid {
 				industryResp := models.IndustryResp{
 					Code: industry.Code,
-					Name: industry.Name,
+					Name: industry.NameIn(lang),
 				}
 
 				matchingIndustries = append(matchingIndustries, industryResp)
@@ -164,16 +184,183 @@ func getAllMatchingIndustries(querySl []string, scrubberDB db.ScrubberDB) []mode
 	return matchingIndustries
 }
 
-func getRequestID(ctx context.Context) string {
-	requestID := ctx.Value(request.RequestIdKey)
-	if requestID == nil {
-		requestID = ctx.Value("request-id")
+// addIndustriesBySynonym adds the industries whose synonyms are found in
+// terms, recording the synonym that matched each one, and returns the terms
+// that are not part of a synonym. Industries already matched are not added
+// again.
+func addIndustriesBySynonym(matchingIndustries []models.IndustryResp, terms []string, lang string, scrubberDB db.ScrubberDB) ([]models.IndustryResp, []string) {
+	found := make(map[string]bool, len(matchingIndustries))
+	for _, industry := range matchingIndustries {
+		found[industry.Code] = true
+	}
+
+	matches := scrubberDB.Synonyms.Find(terms)
+
+	for _, match := range matches {
+		for _, synonym := range match.Values {
+			if found[synonym.Code] {
+				continue
+			}
+
+			for _, industry := range scrubberDB.Industries.Get(synonym.Code) {
+				found[synonym.Code] = true
+
+				matchingIndustries = append(matchingIndustries, models.IndustryResp{
+					Code:    industry.Code,
+					Name:    industry.NameIn(lang),
+					Synonym: synonym.Term,
+				})
+			}
+		}
+	}
+
+	return matchingIndustries, db.Unmatched(terms, matches)
+}
+
+// addIndustriesByName adds the industries whose names best match terms,
+// in English or Welsh, unless they are already matched
+func addIndustriesByName(matchingIndustries []models.IndustryResp, terms []string, lang string, scrubberDB db.ScrubberDB) []models.IndustryResp {
+	for _, name := range scrubberDB.IndustryNames.Find(terms) {
+		industry := name.Industry
+
+		if !slices.ContainsFunc(matchingIndustries, func(i models.IndustryResp) bool { return i.Code == industry.Code }) {
+			matchingIndustries = append(matchingIndustries, models.IndustryResp{
+				Code: industry.Code,
+				Name: industry.NameIn(lang),
+			})
+		}
+	}
+
+	return matchingIndustries
+}
+
+// getAreasByName returns the local authorities, regions and places named in
+// terms, in English or Welsh, and the terms that are not part of a name. A
+// name shared by more than one area, such as Newport, is returned as an
+// ambiguity with a candidate for each, and the areas selected by policy are
+// returned marked as ambiguous.
+func getAreasByName(terms []string, lang, policy string, scrubberDB db.ScrubberDB) ([]models.AreaResp, []models.AmbiguityResp, []string) {
+	var (
+		areas       []models.AreaResp
+		ambiguities []models.AmbiguityResp
+	)
+
+	found := make(map[string]bool)
+	matches := scrubberDB.AreaNames.Find(terms)
+
+	for _, match := range matches {
+		names := nameCandidates(match.Values)
+		ambiguous := len(names) > 1
+
+		if ambiguous {
+			var ambiguity models.AmbiguityResp
+
+			ambiguity, names = selectNames(names, lang, policy)
+			ambiguities = append(ambiguities, ambiguity)
+		}
+
+		for _, name := range names {
+			if found[name.Level+name.Code] {
+				continue
+			}
+
+			found[name.Level+name.Code] = true
+
+			areaResp := areaNameResp(name, lang)
+			areaResp.Ambiguous = ambiguous
+
+			areas = append(areas, areaResp)
+		}
+	}
+
+	return areas, ambiguities, db.Unmatched(terms, matches)
+}
+
+// selectNames scores the areas sharing a name, weighing local authorities and
+// regions above places, and returns them as an ambiguity along with the ones
+// selected by policy
+func selectNames(names []db.AreaName, lang, policy string) (models.AmbiguityResp, []db.AreaName) {
+	ambiguity := models.AmbiguityResp{Term: names[0].Name}
+	weights := make([]float64, 0, len(names))
+
+	for _, name := range names {
+		ambiguity.Candidates = append(ambiguity.Candidates, models.CandidateResp{Kind: name.Level, Code: name.Code, Name: name.NameIn(lang)})
+
+		if name.Level == db.LevelPlace {
+			weights = append(weights, 1)
+		} else {
+			weights = append(weights, 2)
+		}
+	}
+
+	models.ScoreCandidates(ambiguity.Candidates, weights)
+	models.SelectCandidates(ambiguity.Candidates, policy)
+
+	var selected []db.AreaName
+
+	for _, candidate := range ambiguity.Candidates {
+		if !candidate.Selected {
+			continue
+		}
+
+		i := slices.IndexFunc(names, func(n db.AreaName) bool { return n.Level == candidate.Kind && n.Code == candidate.Code })
+		selected = append(selected, names[i])
+	}
+
+	return ambiguity, selected
+}
+
+// nameCandidates returns the distinct areas of names, which all share one
+// name. A place named after the local authority it is in, such as the city
+// of Cardiff, is the local authority itself and is left out.
+func nameCandidates(names []db.AreaName) []db.AreaName {
+	var candidates []db.AreaName
+
+	for _, name := range names {
+		if slices.ContainsFunc(candidates, func(c db.AreaName) bool { return c.Level == name.Level && c.Code == name.Code }) {
+			continue
+		}
+
+		if name.Level == db.LevelPlace && slices.ContainsFunc(names, func(n db.AreaName) bool {
+			return n.Level == db.LevelLocalAuthority && slices.Contains(name.Place.LocalAuthorityCodes, n.Code)
+		}) {
+			continue
+		}
+
+		candidates = append(candidates, name)
+	}
+
+	return candidates
+}
+
+// areaNameResp returns the response for an area found by name, with its
+// names in lang
+func areaNameResp(name db.AreaName, lang string) models.AreaResp {
+	hierarchy := name.Hierarchy()
+
+	areaResp := models.AreaResp{
+		Level:      name.Level,
+		Code:       name.Code,
+		Name:       name.NameIn(lang),
+		Region:     name.Area.RegionNameIn(lang),
+		RegionCode: name.Area.RegionCode,
+		Hierarchy:  geographyResps(hierarchy, lang),
+	}
+
+	if country, ok := countryOf(hierarchy); ok {
+		areaResp.Country = country.NameIn(lang)
+		areaResp.CountryCode = country.Code
 	}
 
-	correlationID, ok := requestID.(string)
-	if !ok {
-		return ""
+	if name.Place != nil && len(name.Place.OutputAreaCodes) > 0 {
+		areaResp.Codes = make(map[string]string, len(name.Place.OutputAreaCodes))
+
+		for _, code := range name.Place.OutputAreaCodes {
+			areaResp.Codes[code] = code
+		}
+
+		areaResp.Count = len(areaResp.Codes)
 	}
 
-	return correlationID
+	return areaResp
 }
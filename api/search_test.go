@@ -4,7 +4,9 @@ import (
 	"testing"
 
 	"github.com/ONSdigital/dp-search-scrubber-api/api/mock"
+	"github.com/ONSdigital/dp-search-scrubber-api/db"
 	"github.com/ONSdigital/dp-search-scrubber-api/models"
+	"github.com/ONSdigital/dp-search-scrubber-api/text"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -30,7 +32,7 @@ func TestEmptyDB(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			matchingIndustries := getAllMatchingIndustries(tt.query, mockDB)
+			matchingIndustries := getAllMatchingIndustries(tt.query, models.LanguageEnglish, mockDB)
 			assert.Equal(t, len(tt.expectedCodes), len(matchingIndustries), "expected %d matching industries, got %d", len(tt.expectedCodes), len(matchingIndustries))
 			for i, industryResp := range matchingIndustries {
 				assert.Equal(t, tt.expectedCodes[i], industryResp.Code, "expected industry with code %s, got %s", tt.expectedCodes[i], industryResp.Code)
@@ -77,7 +79,7 @@ func TestGetAllMatchingIndustries(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			matchingIndustries := getAllMatchingIndustries(tt.query, mockDB)
+			matchingIndustries := getAllMatchingIndustries(tt.query, models.LanguageEnglish, mockDB)
 			assert.Equal(t, len(tt.expectedCodes), len(matchingIndustries), "expected %d matching industries, got %d", len(tt.expectedCodes), len(matchingIndustries))
 			for i, industryResp := range matchingIndustries {
 				assert.Equal(t, tt.expectedCodes[i], industryResp.Code, "expected industry with code %s, got %s", tt.expectedCodes[i], industryResp.Code)
@@ -170,12 +172,41 @@ func TestGetAllMatchingAreas(t *testing.T) {
 			query:         []string{"foo", "bar"},
 			expectedNames: []*models.AreaResp{},
 		},
+		{
+			name:  "matching LSOA query",
+			query: []string{"LSOA1"},
+			expectedNames: []*models.AreaResp{
+				{
+					Name:       "LAN1",
+					Region:     "RN1",
+					RegionCode: "RC1",
+					Codes: map[string]string{
+						"OAC1": "OAC1",
+					},
+				},
+			},
+		},
+		{
+			name:  "matching MSOA query",
+			query: []string{"MSOA1"},
+			expectedNames: []*models.AreaResp{
+				{
+					Name:       "LAN1",
+					Region:     "RN1",
+					RegionCode: "RC1",
+					Codes: map[string]string{
+						"OAC1": "OAC1",
+						"OAC4": "OAC4",
+					},
+				},
+			},
+		},
 	}
 
 	// run tests
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			matchingAreas := getAllMatchingAreas(tt.query, mockDB)
+			matchingAreas := getAllMatchingAreas(tt.query, nil, models.GroupByLA, models.LanguageEnglish, mockDB)
 
 			assert.Equal(t, len(tt.expectedNames), len(matchingAreas),
 				"expected %d matching areas, got %d", len(tt.expectedNames), len(matchingAreas))
@@ -184,7 +215,254 @@ func TestGetAllMatchingAreas(t *testing.T) {
 				assert.Equal(t, tt.expectedNames[i].Name, areaResp.Name)
 				assert.Equal(t, tt.expectedNames[i].Region, areaResp.Region)
 				assert.Equal(t, tt.expectedNames[i].RegionCode, areaResp.RegionCode)
+				assert.Equal(t, tt.expectedNames[i].Codes, areaResp.Codes)
 			}
 		})
 	}
 }
+
+func TestGetAllMatchingAreasHierarchy(t *testing.T) {
+	mockDB := mock.DB()
+
+	tests := []struct {
+		name              string
+		query             []string
+		expectedHierarchy []models.GeographyResp
+	}{
+		{
+			name:  "single output area has all of its ancestors",
+			query: []string{"OAC1"},
+			expectedHierarchy: []models.GeographyResp{
+				{Level: "lsoa", Code: "LSOA1", Name: "LSOAN1"},
+				{Level: "msoa", Code: "MSOA1", Name: "MSOAN1"},
+				{Level: "local_authority", Code: "LAC1", Name: "LAN1"},
+				{Level: "region", Code: "RC1", Name: "RN1"},
+			},
+		},
+		{
+			name:  "output areas in different LSOAs share the MSOA and above",
+			query: []string{"OAC1", "OAC4"},
+			expectedHierarchy: []models.GeographyResp{
+				{Level: "msoa", Code: "MSOA1", Name: "MSOAN1"},
+				{Level: "local_authority", Code: "LAC1", Name: "LAN1"},
+				{Level: "region", Code: "RC1", Name: "RN1"},
+			},
+		},
+		{
+			name:  "output area without a lookup starts at its local authority",
+			query: []string{"OAC2"},
+			expectedHierarchy: []models.GeographyResp{
+				{Level: "local_authority", Code: "LAC2", Name: "LAN2"},
+				{Level: "region", Code: "RC2", Name: "RN2"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matchingAreas := getAllMatchingAreas(tt.query, nil, models.GroupByLA, models.LanguageEnglish, mockDB)
+
+			assert.Len(t, matchingAreas, 1)
+			assert.Equal(t, tt.expectedHierarchy, matchingAreas[0].Hierarchy)
+		})
+	}
+}
+
+func TestGetAllMatchingAreasByCoordinates(t *testing.T) {
+	mockDB := mock.DB()
+
+	matchingAreas := getAllMatchingAreas([]string{"OAC2"}, []models.Coordinate{
+		{Latitude: 52.5, Longitude: -1.9},
+		{Latitude: 53.4, Longitude: -2.2},
+	}, models.GroupByNone, models.LanguageEnglish, mockDB)
+
+	assert.Len(t, matchingAreas, 2)
+	assert.Equal(t, "OAC2", matchingAreas[0].Code)
+	assert.Equal(t, "OAC3", matchingAreas[1].Code)
+}
+
+func TestGetCountries(t *testing.T) {
+	params := &models.ScrubberParams{
+		Codes:     map[string][]string{models.CodeOutputArea: {"S00088956"}},
+		Countries: []string{models.CountryWales},
+	}
+
+	areas := []models.AreaResp{
+		{Codes: map[string]string{"E00000001": "E00000001", "N00000001": "N00000001"}},
+	}
+
+	// S00088956 matched no area, so names no country
+	assert.Equal(t, []models.GeographyResp{
+		{Level: "country", Code: models.CountryEngland, Name: "England"},
+		{Level: "country", Code: models.CountryWales, Name: "Wales"},
+		{Level: "country", Code: models.CountryNorthernIreland, Name: "Northern Ireland"},
+	}, getCountries(params, areas))
+
+	assert.Nil(t, getCountries(&models.ScrubberParams{Codes: map[string][]string{models.CodeOutputArea: {"X12345678"}}}, nil))
+}
+
+func TestAddIndustriesBySynonym(t *testing.T) {
+	mockDB := mock.DB()
+
+	matched := []models.IndustryResp{{Code: "IND3", Name: "Industry 3"}}
+
+	industries, unmatched := addIndustriesBySynonym(matched, text.DefaultStopWords().Terms("Bakeries baking dental surgeons surgeon in london unknown industry"), models.LanguageEnglish, mockDB)
+
+	// IND3 is already matched by its code, and IND4 is not an industry
+	assert.Equal(t, []models.IndustryResp{
+		{Code: "IND3", Name: "Industry 3"},
+		{Code: "IND1", Name: "Industry 1", Synonym: "Baker"},
+		{Code: "IND2", Name: "Industry 2", Synonym: "Dental surgeon"},
+	}, industries)
+	assert.Equal(t, []string{"london"}, unmatched)
+
+	industries, unmatched = addIndustriesBySynonym(nil, []string{"bake"}, models.LanguageEnglish, mock.EmptyDB())
+	assert.Nil(t, industries)
+	assert.Equal(t, []string{"bake"}, unmatched)
+}
+
+func TestAddIndustriesByName(t *testing.T) {
+	mockDB := mock.DB()
+
+	matched := []models.IndustryResp{{Code: "IND1", Name: "Industry 1"}}
+
+	assert.Equal(t, []models.IndustryResp{
+		{Code: "IND1", Name: "Industry 1"},
+		{Code: "IND2", Name: "Industry 2"},
+	}, addIndustriesByName(matched, text.DefaultStopWords().Terms("industries 2"), models.LanguageEnglish, mockDB))
+
+	assert.Equal(t, matched, addIndustriesByName(matched, text.DefaultStopWords().Terms("industry 1"), models.LanguageEnglish, mockDB))
+}
+
+func TestGetAreasByName(t *testing.T) {
+	mockDB := mock.DB()
+
+	areas, _, unmatched := getAreasByName(text.DefaultStopWords().Terms("dentists in LAN1 and RN3"), models.LanguageEnglish, models.AmbiguityFirst, mockDB)
+
+	assert.Equal(t, []string{"dentist"}, unmatched)
+
+	if assert.Len(t, areas, 2) {
+		assert.Equal(t, db.LevelLocalAuthority, areas[0].Level)
+		assert.Equal(t, "LAC1", areas[0].Code)
+		assert.Equal(t, "LAN1", areas[0].Name)
+		assert.Equal(t, "RC1", areas[0].RegionCode)
+		assert.Empty(t, areas[0].Codes)
+
+		assert.Equal(t, db.LevelRegion, areas[1].Level)
+		assert.Equal(t, "RC3", areas[1].Code)
+		assert.Equal(t, []models.GeographyResp{{Level: db.LevelRegion, Code: "RC3", Name: "RN3"}}, areas[1].Hierarchy)
+	}
+}
+
+func TestGetAreasByNameWelsh(t *testing.T) {
+	mockDB := mock.DB()
+
+	// found by its Welsh name, and named in the language asked for
+	for lang, expected := range map[string]string{models.LanguageEnglish: "LAN3", models.LanguageWelsh: "LAN3CY"} {
+		areas, _, _ := getAreasByName(text.DefaultStopWords().Terms("LAN3CY"), lang, models.AmbiguityFirst, mockDB)
+
+		if assert.Len(t, areas, 1) {
+			assert.Equal(t, "LAC3", areas[0].Code)
+			assert.Equal(t, expected, areas[0].Name)
+		}
+	}
+}
+
+func TestAddIndustriesByNameWelsh(t *testing.T) {
+	mockDB := mock.DB()
+
+	assert.Equal(t, []models.IndustryResp{
+		{Code: "IND2", Name: "Diwydiant 2"},
+	}, addIndustriesByName(nil, text.DefaultStopWords().Terms("diwydiant 2"), models.LanguageWelsh, mockDB))
+
+	// industries without a Welsh name are given in English
+	assert.Equal(t, []models.IndustryResp{
+		{Code: "IND1", Name: "Industry 1"},
+	}, getAllMatchingIndustries([]string{"IND1"}, models.LanguageWelsh, mockDB))
+}
+
+func TestGetAreasByNamePlaces(t *testing.T) {
+	mockDB := mock.DB()
+
+	areas, _, unmatched := getAreasByName(text.DefaultStopWords().Terms("dentists in Newport, Crawley and LAN1"), models.LanguageEnglish, models.AmbiguityAll, mockDB)
+
+	assert.Equal(t, []string{"dentist"}, unmatched)
+
+	if assert.Len(t, areas, 4) {
+		assert.Equal(t, db.LevelPlace, areas[0].Level)
+		assert.Equal(t, "BUA1", areas[0].Code)
+		assert.Equal(t, "Newport", areas[0].Name)
+		assert.Equal(t, map[string]string{"OAC2": "OAC2"}, areas[0].Codes)
+		assert.Equal(t, 1, areas[0].Count)
+		assert.Equal(t, "RC2", areas[0].RegionCode)
+		assert.Equal(t, []models.GeographyResp{
+			{Level: db.LevelPlace, Code: "BUA1", Name: "Newport"},
+			{Level: db.LevelLocalAuthority, Code: "LAC2", Name: "LAN2"},
+			{Level: db.LevelRegion, Code: "RC2", Name: "RN2"},
+		}, areas[0].Hierarchy)
+		assert.True(t, areas[0].Ambiguous)
+
+		assert.Equal(t, "BUA2", areas[1].Code)
+		assert.Equal(t, "LAC3", areas[1].Hierarchy[1].Code)
+		assert.True(t, areas[1].Ambiguous)
+
+		// a place with no output areas is given by its local authority
+		assert.Equal(t, "BUA4", areas[2].Code)
+		assert.Empty(t, areas[2].Codes)
+		assert.Equal(t, "LAC1", areas[2].Hierarchy[1].Code)
+		assert.False(t, areas[2].Ambiguous)
+
+		// the place named after its local authority is the local authority
+		assert.Equal(t, db.LevelLocalAuthority, areas[3].Level)
+		assert.Equal(t, "LAC1", areas[3].Code)
+		assert.False(t, areas[3].Ambiguous)
+	}
+
+	// a place across local authorities has each of them in its hierarchy
+	areas, _, _ = getAreasByName(text.DefaultStopWords().Terms("Bordertown"), models.LanguageEnglish, models.AmbiguityAll, mockDB)
+	if assert.Len(t, areas, 1) {
+		assert.Equal(t, map[string]string{"OAC1": "OAC1", "OAC2": "OAC2"}, areas[0].Codes)
+		assert.Equal(t, []models.GeographyResp{
+			{Level: db.LevelPlace, Code: "BUA6", Name: "Bordertown"},
+			{Level: db.LevelLocalAuthority, Code: "LAC1", Name: "LAN1"},
+			{Level: db.LevelLocalAuthority, Code: "LAC2", Name: "LAN2"},
+			{Level: db.LevelRegion, Code: "RC1", Name: "RN1"},
+			{Level: db.LevelRegion, Code: "RC2", Name: "RN2"},
+		}, areas[0].Hierarchy)
+	}
+
+	// places in local authorities without areas are left out
+	areas, _, unmatched = getAreasByName(text.DefaultStopWords().Terms("Harrogate"), models.LanguageEnglish, models.AmbiguityAll, mockDB)
+	assert.Empty(t, areas)
+	assert.Equal(t, []string{"harrogat"}, unmatched)
+}
+
+func TestGetAreasByNameAmbiguity(t *testing.T) {
+	mockDB := mock.DB()
+
+	expected := []models.AmbiguityResp{
+		{
+			Term: "Newport",
+			Candidates: []models.CandidateResp{
+				{Kind: db.LevelPlace, Code: "BUA1", Name: "Newport", Score: 0.5, Selected: true},
+				{Kind: db.LevelPlace, Code: "BUA2", Name: "Newport", Score: 0.5},
+			},
+		},
+	}
+
+	areas, ambiguities, _ := getAreasByName(text.DefaultStopWords().Terms("Newport"), models.LanguageEnglish, models.AmbiguityFirst, mockDB)
+
+	assert.Equal(t, expected, ambiguities)
+
+	if assert.Len(t, areas, 1) {
+		assert.Equal(t, "BUA1", areas[0].Code)
+		assert.True(t, areas[0].Ambiguous)
+	}
+
+	areas, ambiguities, unmatched := getAreasByName(text.DefaultStopWords().Terms("Newport"), models.LanguageEnglish, models.AmbiguityNone, mockDB)
+
+	expected[0].Candidates[0].Selected = false
+	assert.Equal(t, expected, ambiguities)
+	assert.Empty(t, areas)
+	assert.Empty(t, unmatched)
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/db"
+	"github.com/ONSdigital/dp-search-scrubber-api/models"
+)
+
+// currentCodes replaces the retired codes among codes with the codes that
+// succeeded them, returning the retired codes each successor was mapped from.
+// A code found in the area data is taken to be current whatever its history.
+func currentCodes(codes []string, scrubberDB db.ScrubberDB) (current []string, mappedFrom map[string][]models.GeographyResp) {
+	for _, code := range codes {
+		code = strings.ToUpper(code)
+
+		successors := scrubberDB.CodeHistory.Current(code)
+		if len(successors) == 0 || len(scrubberDB.AreasByCode(code)) > 0 || len(scrubberDB.AreaCodes.Get(code)) > 0 {
+			current = appendUnique(current, code)
+			continue
+		}
+
+		if mappedFrom == nil {
+			mappedFrom = make(map[string][]models.GeographyResp)
+		}
+
+		retired := models.GeographyResp{Code: code, Name: scrubberDB.CodeHistory.Changes(code)[0].Name}
+
+		for _, successor := range successors {
+			current = appendUnique(current, successor)
+			mappedFrom[successor] = append(mappedFrom[successor], retired)
+		}
+	}
+
+	return current, mappedFrom
+}
+
+// getAreasByCode returns the local authorities and regions whose codes are
+// in codes
+func getAreasByCode(codes []string, lang string, scrubberDB db.ScrubberDB) []models.AreaResp {
+	var areas []models.AreaResp
+
+	for _, code := range codes {
+		if names := scrubberDB.AreaCodes.Get(code); len(names) > 0 {
+			areas = append(areas, areaNameResp(names[0], lang))
+		}
+	}
+
+	return areas
+}
+
+// markMappedFrom records on each of areas the retired codes it was found by,
+// whether it is a successor itself or holds output areas that are
+func markMappedFrom(areas []models.AreaResp, mappedFrom map[string][]models.GeographyResp) {
+	for i := range areas {
+		for successor, retired := range mappedFrom {
+			if areas[i].Code != successor && areas[i].Codes[successor] == "" {
+				continue
+			}
+
+			for _, code := range retired {
+				if !slices.Contains(areas[i].MappedFrom, code) {
+					areas[i].MappedFrom = append(areas[i].MappedFrom, code)
+				}
+			}
+		}
+
+		slices.SortFunc(areas[i].MappedFrom, func(a, b models.GeographyResp) int { return strings.Compare(a.Code, b.Code) })
+	}
+}
+
+func appendUnique(values []string, value string) []string {
+	if slices.Contains(values, value) {
+		return values
+	}
+
+	return append(values, value)
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/api/mock"
+	"github.com/ONSdigital/dp-search-scrubber-api/db"
+	"github.com/ONSdigital/dp-search-scrubber-api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCurrentCodes(t *testing.T) {
+	current, mappedFrom := currentCodes([]string{"lac8", "OAC9", "OAC1", "LAC1", "OAC7"}, mock.DB())
+
+	assert.Equal(t, []string{"LAC1", "OAC2", "OAC3", "OAC1", "OAC7"}, current)
+	assert.Equal(t, map[string][]models.GeographyResp{
+		"LAC1": {{Code: "LAC8", Name: "Old LA 8"}},
+		"OAC2": {{Code: "OAC9", Name: "Old OA 9"}},
+		"OAC3": {{Code: "OAC9", Name: "Old OA 9"}},
+	}, mappedFrom)
+
+	current, mappedFrom = currentCodes([]string{"LAC8"}, mock.EmptyDB())
+	assert.Equal(t, []string{"LAC8"}, current)
+	assert.Nil(t, mappedFrom)
+}
+
+func TestOutputAreaRecogniserRetiredCodes(t *testing.T) {
+	mockDB := mock.DB()
+
+	var results models.Results
+
+	outputAreaRecogniser{}.Resolve([]string{"LAC8", "OAC9", "RC3"}, &Request{DB: mockDB, Language: models.LanguageEnglish, GroupBy: models.GroupByNone}, &results)
+
+	if assert.Len(t, results.Areas, 4) {
+		assert.Equal(t, "OAC2", results.Areas[0].Code)
+		assert.Equal(t, []models.GeographyResp{{Code: "OAC9", Name: "Old OA 9"}}, results.Areas[0].MappedFrom)
+
+		assert.Equal(t, "OAC3", results.Areas[1].Code)
+		assert.Equal(t, []models.GeographyResp{{Code: "OAC9", Name: "Old OA 9"}}, results.Areas[1].MappedFrom)
+
+		assert.Equal(t, db.LevelLocalAuthority, results.Areas[2].Level)
+		assert.Equal(t, "LAC1", results.Areas[2].Code)
+		assert.Equal(t, "LAN1", results.Areas[2].Name)
+		assert.Equal(t, []models.GeographyResp{{Code: "LAC8", Name: "Old LA 8"}}, results.Areas[2].MappedFrom)
+
+		assert.Equal(t, db.LevelRegion, results.Areas[3].Level)
+		assert.Equal(t, "RC3", results.Areas[3].Code)
+		assert.Empty(t, results.Areas[3].MappedFrom)
+	}
+}
+
+func TestMarkMappedFromGroups(t *testing.T) {
+	areas := []models.AreaResp{
+		{Code: "LAC2", Codes: map[string]string{"OAC2": "OAC2"}},
+		{Code: "LAC3", Codes: map[string]string{"OAC3": "OAC3"}},
+	}
+
+	markMappedFrom(areas, map[string][]models.GeographyResp{
+		"OAC2": {{Code: "OAC9"}, {Code: "OAC8"}},
+		"LAC2": {{Code: "OAC9"}},
+	})
+
+	assert.Equal(t, []models.GeographyResp{{Code: "OAC8"}, {Code: "OAC9"}}, areas[0].MappedFrom)
+	assert.Empty(t, areas[1].MappedFrom)
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/db"
+	"github.com/ONSdigital/dp-search-scrubber-api/models"
+)
+
+// codeTimeSeries is the name of the kind of code for time series CDIDs
+const codeTimeSeries = "cdid"
+
+// timeSeriesRecogniser finds the time series whose CDIDs are in a query, such
+// as ABMI or D7G7. As a CDID can look like a word, only those in the list of
+// time series are matched, and only when written in upper case or with a
+// digit, so that "sale" is not taken for a CDID.
+type timeSeriesRecogniser struct {
+	series *db.Index[db.TimeSeries]
+}
+
+func (timeSeriesRecogniser) Name() string {
+	return codeTimeSeries
+}
+
+func (timeSeriesRecogniser) Section() string {
+	return "timeseries"
+}
+
+func (r timeSeriesRecogniser) Match(token string) (string, bool) {
+	if len(token) != 4 || !strings.ContainsFunc(token, unicode.IsLetter) {
+		return "", false
+	}
+
+	if token != strings.ToUpper(token) && !strings.ContainsFunc(token, unicode.IsDigit) {
+		return "", false
+	}
+
+	cdid := strings.ToUpper(token)
+
+	return cdid, len(r.series.Get(cdid)) > 0
+}
+
+// Score is high, as only CDIDs in the list of time series are matched
+func (timeSeriesRecogniser) Score(string) float64 {
+	return 0.9
+}
+
+func (r timeSeriesRecogniser) Resolve(codes []string, _ *Request, results *models.Results) {
+	var timeSeries []models.TimeSeriesResp
+
+	for _, cdid := range codes {
+		for _, series := range r.series.Get(cdid) {
+			timeSeries = append(timeSeries, models.TimeSeriesResp{
+				CDID:    series.CDID,
+				Title:   series.Title,
+				Dataset: series.Dataset,
+			})
+		}
+	}
+
+	if len(timeSeries) > 0 {
+		results.Add(r.Section(), timeSeries)
+	}
+}
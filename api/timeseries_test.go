@@ -0,0 +1,58 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/api/mock"
+	"github.com/ONSdigital/dp-search-scrubber-api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeSeriesRecogniserMatch(t *testing.T) {
+	recogniser := timeSeriesRecogniser{series: mock.DB().TimeSeries}
+
+	tests := []struct {
+		token    string
+		expected string
+	}{
+		{token: "ABMI", expected: "ABMI"},
+		{token: "abmi"},
+		{token: "d7g7", expected: "D7G7"},
+		{token: "SALE", expected: "SALE"},
+		{token: "sale"},
+		{token: "Sale"},
+		{token: "MGSX"},
+		{token: "2021"},
+		{token: "ABMIX"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			cdid, ok := recogniser.Match(tt.token)
+			assert.Equal(t, tt.expected != "", ok)
+
+			if ok {
+				assert.Equal(t, tt.expected, cdid)
+			}
+		})
+	}
+}
+
+func TestTimeSeriesRecogniserResolve(t *testing.T) {
+	recogniser := timeSeriesRecogniser{series: mock.DB().TimeSeries}
+
+	var results models.Results
+
+	recogniser.Resolve([]string{"D7G7", "ABMI"}, &Request{}, &results)
+
+	assert.Equal(t, []models.TimeSeriesResp{
+		{CDID: "D7G7", Title: "CPI INDEX 00: ALL ITEMS 2015=100", Dataset: "MM23"},
+		{CDID: "ABMI", Title: "Gross Domestic Product: chained volume measures", Dataset: "PN2"},
+	}, results.Entities["timeseries"])
+
+	var empty models.Results
+
+	recogniser.Resolve(nil, &Request{}, &empty)
+
+	assert.Nil(t, empty.Entities)
+}
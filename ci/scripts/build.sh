@@ -5,7 +5,7 @@ cwd=$(pwd)
 export GOPATH=$cwd/go
 
 pushd dp-search-scrubber-api
-  make build-bin && mv build/$(go env GOOS)-$(go env GOARCH)/* $cwd/build
+  make build-bin snapshot && mv build/$(go env GOOS)-$(go env GOARCH)/* $cwd/build
   mv data/ $cwd/build
   cp Dockerfile.concourse $cwd/build
 popd
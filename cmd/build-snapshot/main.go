@@ -0,0 +1,32 @@
+// Command build-snapshot parses the configured area and industry CSV files and
+// writes them to SNAPSHOT_FILE so the API can skip CSV parsing on startup.
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/config"
+	"github.com/ONSdigital/dp-search-scrubber-api/db"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+const serviceName = "dp-search-scrubber-api-build-snapshot"
+
+func main() {
+	log.Namespace = serviceName
+	ctx := context.Background()
+
+	cfg, err := config.Get()
+	if err != nil {
+		log.Fatal(ctx, "error getting configuration", err)
+		os.Exit(1)
+	}
+
+	if err := db.BuildSnapshot(cfg); err != nil {
+		log.Fatal(ctx, "failed to build snapshot", err, log.Data{"snapshot_file": cfg.SnapshotFile})
+		os.Exit(1)
+	}
+
+	log.Info(ctx, "successfully built snapshot", log.Data{"snapshot_file": cfg.SnapshotFile})
+}
@@ -8,12 +8,32 @@ import (
 
 // Config represents service configuration for dp-search-scrubber-api
 type Config struct {
-	AreaDataFile               string        `envconfig:"AREA_DATA_FILE"`
-	BindAddr                   string        `envconfig:"BIND_ADDR"`
-	GracefulShutdownTimeout    time.Duration `envconfig:"GRACEFUL_SHUTDOWN_TIMEOUT"`
-	HealthCheckInterval        time.Duration `envconfig:"HEALTHCHECK_INTERVAL"`
-	HealthCheckCriticalTimeout time.Duration `envconfig:"HEALTHCHECK_CRITICAL_TIMEOUT"`
-	IndustryDataFile           string        `envconfig:"INDUSTRY_DATA_FILE"`
+	AmbiguityPolicy             string        `envconfig:"AMBIGUITY_POLICY"`
+	AreaDataFile                string        `envconfig:"AREA_DATA_FILE"`
+	AreaLookupFile              string        `envconfig:"AREA_LOOKUP_FILE"`
+	AreaWelshNameFile           string        `envconfig:"AREA_WELSH_NAME_FILE"`
+	AreasWithinMaxBBoxArea      float64       `envconfig:"AREAS_WITHIN_MAX_BBOX_AREA"`
+	AreasWithinMaxRadius        float64       `envconfig:"AREAS_WITHIN_MAX_RADIUS"`
+	BindAddr                    string        `envconfig:"BIND_ADDR"`
+	BoundaryCodeProperty        string        `envconfig:"BOUNDARY_CODE_PROPERTY"`
+	BoundaryDataFile            string        `envconfig:"BOUNDARY_DATA_FILE"`
+	CensusDataFile              string        `envconfig:"CENSUS_DATA_FILE"`
+	CentroidDataFile            string        `envconfig:"CENTROID_DATA_FILE"`
+	CodeHistoryFile             string        `envconfig:"CODE_HISTORY_FILE"`
+	GracefulShutdownTimeout     time.Duration `envconfig:"GRACEFUL_SHUTDOWN_TIMEOUT"`
+	HealthCheckInterval         time.Duration `envconfig:"HEALTHCHECK_INTERVAL"`
+	HealthCheckCriticalTimeout  time.Duration `envconfig:"HEALTHCHECK_CRITICAL_TIMEOUT"`
+	IndustryConcordanceFile     string        `envconfig:"INDUSTRY_CONCORDANCE_FILE"`
+	IndustryDataFile            string        `envconfig:"INDUSTRY_DATA_FILE"`
+	IndustrySynonymFile         string        `envconfig:"INDUSTRY_SYNONYM_FILE"`
+	IndustryWelshDataFile       string        `envconfig:"INDUSTRY_WELSH_DATA_FILE"`
+	NorthernIrelandAreaDataFile string        `envconfig:"NORTHERN_IRELAND_AREA_DATA_FILE"`
+	OccupationDataFile          string        `envconfig:"OCCUPATION_DATA_FILE"`
+	PlaceDataFile               string        `envconfig:"PLACE_DATA_FILE"`
+	ScotlandAreaDataFile        string        `envconfig:"SCOTLAND_AREA_DATA_FILE"`
+	SnapshotFile                string        `envconfig:"SNAPSHOT_FILE"`
+	StopWordFile                string        `envconfig:"STOP_WORD_FILE"`
+	TimeSeriesDataFile          string        `envconfig:"TIME_SERIES_DATA_FILE"`
 }
 
 var cfg *Config
@@ -22,12 +42,18 @@ var cfg *Config
 // variables
 func Get() (*Config, error) {
 	cfg = &Config{
+		AmbiguityPolicy:            "first",
 		AreaDataFile:               "data/2011 OAC Clusters and Names csv v2.csv",
+		AreasWithinMaxBBoxArea:     2500,
+		AreasWithinMaxRadius:       25000,
 		BindAddr:                   ":28700",
+		BoundaryCodeProperty:       "OA11CD",
 		GracefulShutdownTimeout:    5 * time.Second,
 		HealthCheckInterval:        30 * time.Second,
 		HealthCheckCriticalTimeout: 90 * time.Second,
 		IndustryDataFile:           "data/SIC07_CH_condensed_list_en.csv",
+		IndustrySynonymFile:        "data/SIC07_synonyms_en.csv",
+		SnapshotFile:               "data/scrubber.snapshot",
 	}
 
 	return cfg, envconfig.Process("", cfg)
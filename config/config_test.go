@@ -18,8 +18,28 @@ func TestGetDefaultConfig(t *testing.T) {
 	assert.Equal(t, 5*time.Second, config.GracefulShutdownTimeout)
 	assert.Equal(t, 30*time.Second, config.HealthCheckInterval)
 	assert.Equal(t, 90*time.Second, config.HealthCheckCriticalTimeout)
+	assert.Equal(t, "first", config.AmbiguityPolicy)
 	assert.Equal(t, "data/2011 OAC Clusters and Names csv v2.csv", config.AreaDataFile)
+	assert.Equal(t, 2500.0, config.AreasWithinMaxBBoxArea)
+	assert.Equal(t, 25000.0, config.AreasWithinMaxRadius)
+	assert.Equal(t, "", config.IndustryConcordanceFile)
 	assert.Equal(t, "data/SIC07_CH_condensed_list_en.csv", config.IndustryDataFile)
+	assert.Equal(t, "data/scrubber.snapshot", config.SnapshotFile)
+	assert.Equal(t, "", config.AreaLookupFile)
+	assert.Equal(t, "", config.CensusDataFile)
+	assert.Equal(t, "", config.CentroidDataFile)
+	assert.Equal(t, "", config.CodeHistoryFile)
+	assert.Equal(t, "", config.BoundaryDataFile)
+	assert.Equal(t, "OA11CD", config.BoundaryCodeProperty)
+	assert.Equal(t, "", config.ScotlandAreaDataFile)
+	assert.Equal(t, "", config.NorthernIrelandAreaDataFile)
+	assert.Equal(t, "", config.OccupationDataFile)
+	assert.Equal(t, "", config.PlaceDataFile)
+	assert.Equal(t, "data/SIC07_synonyms_en.csv", config.IndustrySynonymFile)
+	assert.Equal(t, "", config.StopWordFile)
+	assert.Equal(t, "", config.TimeSeriesDataFile)
+	assert.Equal(t, "", config.AreaWelshNameFile)
+	assert.Equal(t, "", config.IndustryWelshDataFile)
 }
 
 func TestGetConfigFromEnv(t *testing.T) {
@@ -28,8 +48,28 @@ func TestGetConfigFromEnv(t *testing.T) {
 	os.Setenv("GRACEFUL_SHUTDOWN_TIMEOUT", "10s")
 	os.Setenv("HEALTHCHECK_INTERVAL", "60s")
 	os.Setenv("HEALTHCHECK_CRITICAL_TIMEOUT", "180s")
+	os.Setenv("AMBIGUITY_POLICY", "all")
 	os.Setenv("AREA_DATA_FILE", "data/areas.csv")
+	os.Setenv("AREAS_WITHIN_MAX_BBOX_AREA", "100")
+	os.Setenv("AREAS_WITHIN_MAX_RADIUS", "5000")
+	os.Setenv("INDUSTRY_CONCORDANCE_FILE", "data/concordance.csv")
 	os.Setenv("INDUSTRY_DATA_FILE", "data/industries.csv")
+	os.Setenv("SNAPSHOT_FILE", "data/index.snapshot")
+	os.Setenv("AREA_LOOKUP_FILE", "data/lookup.csv")
+	os.Setenv("CENSUS_DATA_FILE", "data/census.csv")
+	os.Setenv("CENTROID_DATA_FILE", "data/centroids.csv")
+	os.Setenv("CODE_HISTORY_FILE", "data/code_history.csv")
+	os.Setenv("SCOTLAND_AREA_DATA_FILE", "data/scotland.csv")
+	os.Setenv("INDUSTRY_SYNONYM_FILE", "data/sic_index.csv")
+	os.Setenv("NORTHERN_IRELAND_AREA_DATA_FILE", "data/northern_ireland.csv")
+	os.Setenv("OCCUPATION_DATA_FILE", "data/soc2020.csv")
+	os.Setenv("PLACE_DATA_FILE", "data/places.csv")
+	os.Setenv("BOUNDARY_DATA_FILE", "data/boundaries.geojson")
+	os.Setenv("BOUNDARY_CODE_PROPERTY", "OA21CD")
+	os.Setenv("STOP_WORD_FILE", "data/stopwords.csv")
+	os.Setenv("TIME_SERIES_DATA_FILE", "data/timeseries.csv")
+	os.Setenv("AREA_WELSH_NAME_FILE", "data/area_names_cy.csv")
+	os.Setenv("INDUSTRY_WELSH_DATA_FILE", "data/SIC07_CH_condensed_list_cy.csv")
 
 	// Call the Get function to get the modified configuration
 	config, err := Get()
@@ -40,14 +80,54 @@ func TestGetConfigFromEnv(t *testing.T) {
 	assert.Equal(t, 10*time.Second, config.GracefulShutdownTimeout)
 	assert.Equal(t, 60*time.Second, config.HealthCheckInterval)
 	assert.Equal(t, 180*time.Second, config.HealthCheckCriticalTimeout)
+	assert.Equal(t, "all", config.AmbiguityPolicy)
 	assert.Equal(t, "data/areas.csv", config.AreaDataFile)
+	assert.Equal(t, 100.0, config.AreasWithinMaxBBoxArea)
+	assert.Equal(t, 5000.0, config.AreasWithinMaxRadius)
+	assert.Equal(t, "data/concordance.csv", config.IndustryConcordanceFile)
 	assert.Equal(t, "data/industries.csv", config.IndustryDataFile)
+	assert.Equal(t, "data/index.snapshot", config.SnapshotFile)
+	assert.Equal(t, "data/lookup.csv", config.AreaLookupFile)
+	assert.Equal(t, "data/census.csv", config.CensusDataFile)
+	assert.Equal(t, "data/centroids.csv", config.CentroidDataFile)
+	assert.Equal(t, "data/code_history.csv", config.CodeHistoryFile)
+	assert.Equal(t, "data/scotland.csv", config.ScotlandAreaDataFile)
+	assert.Equal(t, "data/sic_index.csv", config.IndustrySynonymFile)
+	assert.Equal(t, "data/northern_ireland.csv", config.NorthernIrelandAreaDataFile)
+	assert.Equal(t, "data/soc2020.csv", config.OccupationDataFile)
+	assert.Equal(t, "data/places.csv", config.PlaceDataFile)
+	assert.Equal(t, "data/boundaries.geojson", config.BoundaryDataFile)
+	assert.Equal(t, "OA21CD", config.BoundaryCodeProperty)
+	assert.Equal(t, "data/stopwords.csv", config.StopWordFile)
+	assert.Equal(t, "data/timeseries.csv", config.TimeSeriesDataFile)
+	assert.Equal(t, "data/area_names_cy.csv", config.AreaWelshNameFile)
+	assert.Equal(t, "data/SIC07_CH_condensed_list_cy.csv", config.IndustryWelshDataFile)
 
 	// Unset the environment variables
 	os.Unsetenv("BIND_ADDR")
 	os.Unsetenv("GRACEFUL_SHUTDOWN_TIMEOUT")
 	os.Unsetenv("HEALTHCHECK_INTERVAL")
 	os.Unsetenv("HEALTHCHECK_CRITICAL_TIMEOUT")
+	os.Unsetenv("AMBIGUITY_POLICY")
 	os.Unsetenv("AREA_DATA_FILE")
+	os.Unsetenv("AREAS_WITHIN_MAX_BBOX_AREA")
+	os.Unsetenv("AREAS_WITHIN_MAX_RADIUS")
+	os.Unsetenv("INDUSTRY_CONCORDANCE_FILE")
 	os.Unsetenv("INDUSTRY_DATA_FILE")
+	os.Unsetenv("SNAPSHOT_FILE")
+	os.Unsetenv("AREA_LOOKUP_FILE")
+	os.Unsetenv("CENSUS_DATA_FILE")
+	os.Unsetenv("CENTROID_DATA_FILE")
+	os.Unsetenv("CODE_HISTORY_FILE")
+	os.Unsetenv("SCOTLAND_AREA_DATA_FILE")
+	os.Unsetenv("INDUSTRY_SYNONYM_FILE")
+	os.Unsetenv("NORTHERN_IRELAND_AREA_DATA_FILE")
+	os.Unsetenv("OCCUPATION_DATA_FILE")
+	os.Unsetenv("PLACE_DATA_FILE")
+	os.Unsetenv("BOUNDARY_DATA_FILE")
+	os.Unsetenv("BOUNDARY_CODE_PROPERTY")
+	os.Unsetenv("STOP_WORD_FILE")
+	os.Unsetenv("TIME_SERIES_DATA_FILE")
+	os.Unsetenv("AREA_WELSH_NAME_FILE")
+	os.Unsetenv("INDUSTRY_WELSH_DATA_FILE")
 }
@@ -1,22 +1,129 @@
 package db
 
 import (
+	"context"
 	"os"
 
 	"github.com/ONSdigital/dp-search-scrubber-api/config"
+	"github.com/ONSdigital/log.go/v2/log"
 	"github.com/gocarina/gocsv"
 )
 
 type Area struct {
-	OutputAreaCode     string `csv:"Output Area Code"`
-	LocalAuthorityCode string `csv:"Local Authority Code"`
-	LAName             string `csv:"Local Authority Name"`
-	RegionCode         string `csv:"Region/Country Code"`
-	RegionName         string `csv:"Region/Country Name"`
+	OutputAreaCode     string  `csv:"Output Area Code"`
+	LocalAuthorityCode string  `csv:"Local Authority Code"`
+	LAName             string  `csv:"Local Authority Name"`
+	RegionCode         string  `csv:"Region/Country Code"`
+	RegionName         string  `csv:"Region/Country Name"`
+	LAWelshName        string  `csv:"-"`
+	RegionWelshName    string  `csv:"-"`
+	SupergroupCode     string  `csv:"Supergroup Code"`
+	SupergroupName     string  `csv:"Supergroup Name"`
+	LSOACode           string  `csv:"-"`
+	LSOAName           string  `csv:"-"`
+	MSOACode           string  `csv:"-"`
+	MSOAName           string  `csv:"-"`
+	Latitude           float64 `csv:"-"`
+	Longitude          float64 `csv:"-"`
 }
 
+// areaExtra is an optional file adding detail to the area data
+type areaExtra struct {
+	name string
+	file string
+	add  func(cfg *config.Config, areas []Area) error
+}
+
+// areaExtras returns the optional area files that are configured
+func areaExtras(cfg *config.Config) []areaExtra {
+	var extras []areaExtra
+
+	if cfg.AreaLookupFile != "" {
+		extras = append(extras, areaExtra{name: "lookup", file: cfg.AreaLookupFile, add: loadLookup})
+	}
+
+	if cfg.CentroidDataFile != "" {
+		extras = append(extras, areaExtra{name: "centroid", file: cfg.CentroidDataFile, add: loadCentroids})
+	}
+
+	if cfg.AreaWelshNameFile != "" {
+		extras = append(extras, areaExtra{name: "Welsh name", file: cfg.AreaWelshNameFile, add: loadWelshNames})
+	}
+
+	return extras
+}
+
+// AreaLookup is a row of the ONS output area lookup, linking an output area to
+// the LSOA and MSOA it belongs to
+type AreaLookup struct {
+	OutputAreaCode string `csv:"OA11CD"`
+	LSOACode       string `csv:"LSOA11CD"`
+	LSOAName       string `csv:"LSOA11NM"`
+	MSOACode       string `csv:"MSOA11CD"`
+	MSOAName       string `csv:"MSOA11NM"`
+}
+
+// areaDataFiles returns the configured area files, which all share the same
+// columns. The main file covers England and Wales, and the optional files
+// add the output areas of Scotland and the small areas of Northern Ireland.
+func areaDataFiles(cfg *config.Config) []string {
+	files := []string{cfg.AreaDataFile}
+
+	for _, extra := range optionalAreaFiles(cfg) {
+		files = append(files, extra.file)
+	}
+
+	return files
+}
+
+// areaFile is an optional file of more areas, with the same columns as the
+// main area file
+type areaFile struct {
+	name string
+	file string
+}
+
+// optionalAreaFiles returns the optional area files that are configured
+func optionalAreaFiles(cfg *config.Config) []areaFile {
+	var files []areaFile
+
+	if cfg.ScotlandAreaDataFile != "" {
+		files = append(files, areaFile{name: "Scotland", file: cfg.ScotlandAreaDataFile})
+	}
+
+	if cfg.NorthernIrelandAreaDataFile != "" {
+		files = append(files, areaFile{name: "Northern Ireland", file: cfg.NorthernIrelandAreaDataFile})
+	}
+
+	return files
+}
+
+// getArea reads the main area file, which covers England and Wales
 func getArea(cfg *config.Config) ([]Area, error) {
-	file, err := os.Open(cfg.AreaDataFile)
+	return readAreaFile(cfg.AreaDataFile)
+}
+
+// addOptionalAreas adds the areas of the optional area files to areas. A file
+// that cannot be read is logged and left out, as the other areas are still of
+// use without it.
+func addOptionalAreas(ctx context.Context, cfg *config.Config, areas []Area) []Area {
+	for _, extra := range optionalAreaFiles(cfg) {
+		more, err := readAreaFile(extra.file)
+		if err != nil {
+			log.Error(ctx, "Error loading "+extra.name+" Area data: ", err, log.Data{"file": extra.file})
+			continue
+		}
+
+		areas = append(areas, more...)
+
+		log.Info(ctx, "Successfully loaded "+extra.name+" Area data")
+	}
+
+	return areas
+}
+
+func readAreaFile(name string) ([]Area, error) {
+	file, err := os.Open(name)
 	if err != nil {
 		return nil, err
 	}
@@ -31,3 +138,97 @@ func getArea(cfg *config.Config) ([]Area, error) {
 
 	return ar, nil
 }
+
+func getAreaLookup(cfg *config.Config) ([]AreaLookup, error) {
+	file, err := os.Open(cfg.AreaLookupFile)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	al := []AreaLookup{}
+
+	if err := gocsv.UnmarshalFile(file, &al); err != nil {
+		return nil, err
+	}
+
+	return al, nil
+}
+
+func loadLookup(cfg *config.Config, areas []Area) error {
+	lookup, err := getAreaLookup(cfg)
+	if err != nil {
+		return err
+	}
+
+	addLookup(areas, lookup)
+
+	return nil
+}
+
+// addLookup fills in the LSOA and MSOA of each area found in lookup
+func addLookup(areas []Area, lookup []AreaLookup) {
+	byOutputArea := make(map[string]*AreaLookup, len(lookup))
+	for i := range lookup {
+		byOutputArea[lookup[i].OutputAreaCode] = &lookup[i]
+	}
+
+	for i := range areas {
+		if l, found := byOutputArea[areas[i].OutputAreaCode]; found {
+			areas[i].LSOACode = l.LSOACode
+			areas[i].LSOAName = l.LSOAName
+			areas[i].MSOACode = l.MSOACode
+			areas[i].MSOAName = l.MSOAName
+		}
+	}
+}
+
+// Hierarchy returns the chain of geographies the area belongs to, starting
+// with the output area itself and ending with its country. Levels that are
+// not known for the area are left out.
+func (a *Area) Hierarchy() []Geography {
+	chain := []Geography{
+		{Level: LevelOutputArea, Code: a.OutputAreaCode},
+	}
+
+	if a.LSOACode != "" {
+		chain = append(chain, Geography{Level: LevelLSOA, Code: a.LSOACode, Name: a.LSOAName})
+	}
+
+	if a.MSOACode != "" {
+		chain = append(chain, Geography{Level: LevelMSOA, Code: a.MSOACode, Name: a.MSOAName})
+	}
+
+	chain = append(chain, Geography{Level: LevelLocalAuthority, Code: a.LocalAuthorityCode, Name: a.LAName, WelshName: a.LAWelshName})
+
+	country := a.Country()
+
+	// the region of a welsh area is Wales itself
+	if a.RegionCode != country.Code {
+		chain = append(chain, Geography{Level: LevelRegion, Code: a.RegionCode, Name: a.RegionName, WelshName: a.RegionWelshName})
+	}
+
+	if country.Code != "" {
+		chain = append(chain, country)
+	}
+
+	return chain
+}
+
+// LANameIn returns the name of the local authority of the area in language,
+// falling back to English when there is no Welsh name
+func (a *Area) LANameIn(language string) string {
+	return localName(language, a.LAName, a.LAWelshName)
+}
+
+// RegionNameIn returns the name of the region of the area in language,
+// falling back to English when there is no Welsh name
+func (a *Area) RegionNameIn(language string) string {
+	return localName(language, a.RegionName, a.RegionWelshName)
+}
+
+// Country returns the country the area is in, going by its GSS code
+func (a *Area) Country() Geography {
+	return CountryOfCode(a.OutputAreaCode)
+}
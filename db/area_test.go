@@ -1,6 +1,8 @@
 package db
 
 import (
+	"context"
+	"os"
 	"testing"
 
 	"github.com/ONSdigital/dp-search-scrubber-api/config"
@@ -57,3 +59,138 @@ func TestGetArea(t *testing.T) {
 		assert.Equal(t, expected.RegionName, ar[i].RegionName, "RegionName does not match expected value")
 	}
 }
+
+func TestGetAreaWithScotlandAndNorthernIreland(t *testing.T) {
+	files := map[string]string{
+		"scotland.csv":         "Output Area Code,Local Authority Code,Local Authority Name,Region/Country Code,Region/Country Name\nS00088956,S12000033,Aberdeen City,S92000003,Scotland\n",
+		"northern_ireland.csv": "Output Area Code,Local Authority Code,Local Authority Name,Region/Country Code,Region/Country Name\nN00000001,N09000003,Belfast,N92000002,Northern Ireland\n",
+	}
+
+	for name, data := range files {
+		if err := os.WriteFile(name, []byte(data), 0o600); err != nil {
+			t.Fatalf("Failed to write test data: %v", err)
+		}
+		defer os.Remove(name)
+	}
+
+	m := mock.CreateFiles(t)
+	defer m.CloseFiles()
+
+	cfg := config.Config{
+		AreaDataFile:                "area.csv",
+		ScotlandAreaDataFile:        "scotland.csv",
+		NorthernIrelandAreaDataFile: "northern_ireland.csv",
+	}
+
+	ar, err := getArea(&cfg)
+	assert.Nil(t, err)
+
+	ar = addOptionalAreas(context.Background(), &cfg, ar)
+
+	if assert.Len(t, ar, 4) {
+		assert.Equal(t, "S00088956", ar[2].OutputAreaCode)
+		assert.Equal(t, "Scotland", ar[2].Country().Name)
+		assert.Equal(t, "N00000001", ar[3].OutputAreaCode)
+		assert.Equal(t, "Northern Ireland", ar[3].Country().Name)
+	}
+
+	// every configured area file is part of the snapshot
+	assert.Equal(t, []string{"area.csv", "scotland.csv", "northern_ireland.csv", ""}, sourceFiles(&cfg))
+}
+
+func TestGetAreaWithBadScotlandFile(t *testing.T) {
+	if err := os.WriteFile("scotland.csv", []byte("Output Area Code,Local Authority Code\n\"S00088956,S12000033\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write test data: %v", err)
+	}
+	defer os.Remove("scotland.csv")
+
+	m := mock.CreateFiles(t)
+	defer m.CloseFiles()
+
+	cfg := config.Config{
+		AreaDataFile:                "area.csv",
+		IndustryDataFile:            "industry.csv",
+		ScotlandAreaDataFile:        "scotland.csv",
+		NorthernIrelandAreaDataFile: "missing.csv",
+	}
+
+	// the areas of England and Wales are kept without those of the bad files
+	sr := LoadCsvData(context.Background(), &cfg)
+	assert.Equal(t, 2, sr.Areas.Len())
+
+	// but a snapshot is not built from them
+	cfg.SnapshotFile = "bad.snapshot"
+	defer os.Remove(cfg.SnapshotFile)
+
+	assert.NotNil(t, BuildSnapshot(&cfg))
+}
+
+func TestAddLookup(t *testing.T) {
+	areas := []Area{
+		{OutputAreaCode: "E00000001"},
+		{OutputAreaCode: "E00000002"},
+	}
+
+	addLookup(areas, []AreaLookup{
+		{OutputAreaCode: "E00000001", LSOACode: "E01000001", LSOAName: "City of London 001A", MSOACode: "E02000001", MSOAName: "City of London 001"},
+	})
+
+	assert.Equal(t, "E01000001", areas[0].LSOACode)
+	assert.Equal(t, "City of London 001A", areas[0].LSOAName)
+	assert.Equal(t, "E02000001", areas[0].MSOACode)
+	assert.Equal(t, "City of London 001", areas[0].MSOAName)
+	assert.Empty(t, areas[1].LSOACode)
+	assert.Empty(t, areas[1].MSOACode)
+}
+
+func TestAreaHierarchy(t *testing.T) {
+	tests := []struct {
+		name     string
+		area     Area
+		expected []Geography
+	}{
+		{
+			name: "english area with a lookup",
+			area: Area{
+				OutputAreaCode:     "E00000001",
+				LSOACode:           "E01000001",
+				LSOAName:           "City of London 001A",
+				MSOACode:           "E02000001",
+				MSOAName:           "City of London 001",
+				LocalAuthorityCode: "E09000001",
+				LAName:             "City of London",
+				RegionCode:         "E12000007",
+				RegionName:         "London",
+			},
+			expected: []Geography{
+				{Level: LevelOutputArea, Code: "E00000001"},
+				{Level: LevelLSOA, Code: "E01000001", Name: "City of London 001A"},
+				{Level: LevelMSOA, Code: "E02000001", Name: "City of London 001"},
+				{Level: LevelLocalAuthority, Code: "E09000001", Name: "City of London"},
+				{Level: LevelRegion, Code: "E12000007", Name: "London"},
+				{Level: LevelCountry, Code: "E92000001", Name: "England", WelshName: "Lloegr"},
+			},
+		},
+		{
+			name: "welsh area without a lookup",
+			area: Area{
+				OutputAreaCode:     "W00000001",
+				LocalAuthorityCode: "W06000001",
+				LAName:             "Isle of Anglesey",
+				RegionCode:         "W92000004",
+				RegionName:         "Wales",
+			},
+			expected: []Geography{
+				{Level: LevelOutputArea, Code: "W00000001"},
+				{Level: LevelLocalAuthority, Code: "W06000001", Name: "Isle of Anglesey"},
+				{Level: LevelCountry, Code: "W92000004", Name: "Wales", WelshName: "Cymru"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.area.Hierarchy())
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package db
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"strings"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/config"
+	"github.com/ONSdigital/dp-search-scrubber-api/geo"
+)
+
+// boundaryCellSize is the size in degrees of the cells of a BoundaryIndex,
+// a little larger than a typical output area
+const boundaryCellSize = 0.01
+
+// boundary is the outline of an output area, held as rings of longitude and
+// latitude pairs. Holes and the separate parts of areas made up of more than
+// one polygon are all just further rings.
+type boundary struct {
+	area                           *Area
+	rings                          [][]float32
+	minLat, minLon, maxLat, maxLon float64
+}
+
+// contains reports whether the point is inside the boundary, by counting the
+// edges crossed by a line running east from it
+func (b *boundary) contains(lat, lon float64) bool {
+	if lat < b.minLat || lat > b.maxLat || lon < b.minLon || lon > b.maxLon {
+		return false
+	}
+
+	inside := false
+
+	for _, ring := range b.rings {
+		for i, j := 0, len(ring)-2; i < len(ring); j, i = i, i+2 {
+			lon1, lat1 := float64(ring[i]), float64(ring[i+1])
+			lon2, lat2 := float64(ring[j]), float64(ring[j+1])
+
+			if (lat1 > lat) != (lat2 > lat) && lon < (lon2-lon1)*(lat-lat1)/(lat2-lat1)+lon1 {
+				inside = !inside
+			}
+		}
+	}
+
+	return inside
+}
+
+// BoundaryIndex finds the output area containing a point, using a grid of
+// cells each listing the boundaries that overlap it
+type BoundaryIndex struct {
+	boundaries []boundary
+	cells      map[[2]int32][]int32
+}
+
+func newBoundaryIndex(boundaries []boundary) *BoundaryIndex {
+	bi := &BoundaryIndex{
+		boundaries: boundaries,
+		cells:      make(map[[2]int32][]int32),
+	}
+
+	for i := range boundaries {
+		b := &boundaries[i]
+
+		minCell, maxCell := boundaryCell(b.minLat, b.minLon), boundaryCell(b.maxLat, b.maxLon)
+
+		for y := minCell[0]; y <= maxCell[0]; y++ {
+			for x := minCell[1]; x <= maxCell[1]; x++ {
+				bi.cells[[2]int32{y, x}] = append(bi.cells[[2]int32{y, x}], int32(i)) // #nosec G115 -- bounded by the number of output areas
+			}
+		}
+	}
+
+	return bi
+}
+
+func boundaryCell(lat, lon float64) [2]int32 {
+	return [2]int32{int32(math.Floor(lat / boundaryCellSize)), int32(math.Floor(lon / boundaryCellSize))}
+}
+
+// Len returns the number of boundaries held in the index
+func (bi *BoundaryIndex) Len() int {
+	if bi == nil {
+		return 0
+	}
+
+	return len(bi.boundaries)
+}
+
+// Locate returns the output area whose boundary contains the point, or nil if
+// it is not inside any of them
+func (bi *BoundaryIndex) Locate(lat, lon float64) *Area {
+	if bi == nil {
+		return nil
+	}
+
+	for _, i := range bi.cells[boundaryCell(lat, lon)] {
+		if bi.boundaries[i].contains(lat, lon) {
+			return bi.boundaries[i].area
+		}
+	}
+
+	return nil
+}
+
+// newBoundary returns the boundary of area made up of rings of longitude and
+// latitude pairs, or false if none of the rings have enough points
+func newBoundary(area *Area, rings [][][2]float64) (boundary, bool) {
+	b := boundary{
+		area:   area,
+		minLat: math.Inf(1),
+		minLon: math.Inf(1),
+		maxLat: math.Inf(-1),
+		maxLon: math.Inf(-1),
+	}
+
+	for _, ring := range rings {
+		if len(ring) < 3 {
+			continue
+		}
+
+		flat := make([]float32, 0, len(ring)*2)
+
+		for _, p := range ring {
+			flat = append(flat, float32(p[0]), float32(p[1]))
+
+			b.minLon, b.maxLon = math.Min(b.minLon, p[0]), math.Max(b.maxLon, p[0])
+			b.minLat, b.maxLat = math.Min(b.minLat, p[1]), math.Max(b.maxLat, p[1])
+		}
+
+		b.rings = append(b.rings, flat)
+	}
+
+	return b, len(b.rings) > 0
+}
+
+// projection is the coordinate reference system of the points of a boundary
+// file
+type projection int
+
+const (
+	// projectionUnknown is used when a file does not say, so each boundary
+	// is taken to be in eastings and northings if its values are too large
+	// to be longitude and latitude
+	projectionUnknown projection = iota
+	projectionWGS84
+	projectionBNG
+)
+
+// lonLat returns rings given in the projection as longitude and latitude
+// pairs, converting British National Grid eastings and northings to WGS84
+func (p projection) lonLat(rings [][][2]float64) [][][2]float64 {
+	if p == projectionWGS84 || (p == projectionUnknown && !outsideLonLat(rings)) {
+		return rings
+	}
+
+	for _, ring := range rings {
+		for i, point := range ring {
+			lat, lon := geo.OSGBToWGS84(point[0], point[1])
+			ring[i] = [2]float64{lon, lat}
+		}
+	}
+
+	return rings
+}
+
+// outsideLonLat reports whether any point of the rings cannot be a longitude
+// and latitude, as British National Grid eastings and northings of Great
+// Britain never can
+func outsideLonLat(rings [][][2]float64) bool {
+	for _, ring := range rings {
+		for _, point := range ring {
+			if math.Abs(point[0]) > 180 || math.Abs(point[1]) > 90 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// boundaryReader calls add with the output area code and rings of every
+// boundary in a file
+type boundaryReader func(cfg *config.Config, add func(code string, rings [][][2]float64)) error
+
+// getBoundaries reads cfg.BoundaryDataFile, either GeoJSON or a shapefile in
+// longitude and latitude or British National Grid eastings and northings, and
+// returns an index of the boundaries of the output areas found in areas
+func getBoundaries(cfg *config.Config, areas *Index[*Area]) (*BoundaryIndex, error) {
+	var read boundaryReader
+
+	switch strings.ToLower(filepath.Ext(cfg.BoundaryDataFile)) {
+	case ".json", ".geojson":
+		read = readGeoJSONBoundaries
+	case ".shp":
+		read = readShapefileBoundaries
+	default:
+		return nil, fmt.Errorf("unsupported boundary file %q, expected GeoJSON or a shapefile", cfg.BoundaryDataFile)
+	}
+
+	var boundaries []boundary
+
+	err := read(cfg, func(code string, rings [][][2]float64) {
+		for _, area := range areas.Get(code) {
+			if b, ok := newBoundary(area, rings); ok {
+				boundaries = append(boundaries, b)
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newBoundaryIndex(boundaries), nil
+}
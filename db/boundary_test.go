@@ -0,0 +1,269 @@
+package db
+
+import (
+	"encoding/binary"
+	"math"
+	"os"
+	"testing"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/config"
+	"github.com/ONSdigital/dp-search-scrubber-api/geo"
+	"github.com/stretchr/testify/assert"
+)
+
+// square returns a closed ring of longitude and latitude pairs
+func square(minLon, minLat, maxLon, maxLat float64) [][2]float64 {
+	return [][2]float64{{minLon, minLat}, {maxLon, minLat}, {maxLon, maxLat}, {minLon, maxLat}, {minLon, minLat}}
+}
+
+func boundaryAreas() *Index[*Area] {
+	return indexAreas([]Area{
+		{OutputAreaCode: "E00000001", LocalAuthorityCode: "E09000001"},
+		{OutputAreaCode: "E00000002", LocalAuthorityCode: "E09000001"},
+	}, outputAreaKey)
+}
+
+func TestBoundaryContains(t *testing.T) {
+	// a square with a square hole in its middle
+	b, ok := newBoundary(&Area{}, [][][2]float64{square(0, 0, 1, 1), square(0.4, 0.4, 0.6, 0.6)})
+	assert.True(t, ok)
+
+	assert.True(t, b.contains(0.2, 0.2))
+	assert.True(t, b.contains(0.9, 0.5))
+	assert.False(t, b.contains(0.5, 0.5))
+	assert.False(t, b.contains(1.5, 0.5))
+	assert.False(t, b.contains(-0.1, 0.5))
+
+	_, ok = newBoundary(&Area{}, [][][2]float64{{{0, 0}, {1, 1}}})
+	assert.False(t, ok)
+}
+
+func TestBoundaryIndexLocate(t *testing.T) {
+	areas := boundaryAreas()
+
+	first, _ := newBoundary(areas.Get("E00000001")[0], [][][2]float64{square(-0.1, 51.5, -0.09, 51.51)})
+	second, _ := newBoundary(areas.Get("E00000002")[0], [][][2]float64{square(-0.09, 51.5, -0.05, 51.51), square(-0.2, 51.6, -0.19, 51.61)})
+
+	bi := newBoundaryIndex([]boundary{first, second})
+	assert.Equal(t, 2, bi.Len())
+
+	assert.Equal(t, "E00000001", bi.Locate(51.505, -0.095).OutputAreaCode)
+	assert.Equal(t, "E00000002", bi.Locate(51.505, -0.06).OutputAreaCode)
+	assert.Equal(t, "E00000002", bi.Locate(51.605, -0.195).OutputAreaCode)
+	assert.Nil(t, bi.Locate(51.52, -0.095))
+
+	var empty *BoundaryIndex
+	assert.Equal(t, 0, empty.Len())
+	assert.Nil(t, empty.Locate(51.505, -0.095))
+}
+
+func TestScrubberDBLocateOutsideBoundaries(t *testing.T) {
+	areas := []Area{
+		{OutputAreaCode: "E00000001", LocalAuthorityCode: "E09000001", Latitude: 51.505, Longitude: -0.095},
+		{OutputAreaCode: "E00000002", LocalAuthorityCode: "E09000001", Latitude: 51.505, Longitude: -0.07},
+	}
+
+	sdb := NewScrubberDB(areas, nil, nil)
+
+	first, _ := newBoundary(sdb.Areas.Get("E00000001")[0], [][][2]float64{square(-0.1, 51.5, -0.09, 51.51)})
+	second, _ := newBoundary(sdb.Areas.Get("E00000002")[0], [][][2]float64{square(-0.09, 51.5, -0.05, 51.51)})
+	sdb.Boundaries = newBoundaryIndex([]boundary{first, second})
+
+	// inside a boundary, though nearer the other area's centroid
+	assert.Equal(t, "E00000002", sdb.Locate(51.505, -0.089).OutputAreaCode)
+
+	// outside every boundary, so the nearest centroid
+	assert.Equal(t, "E00000002", sdb.Locate(51.52, -0.06).OutputAreaCode)
+	assert.Equal(t, "E00000001", sdb.Locate(51.49, -0.11).OutputAreaCode)
+}
+
+func TestGetBoundariesGeoJSON(t *testing.T) {
+	geoJSON := `{
+		"type": "FeatureCollection",
+		"name": "boundaries",
+		"features": [
+			{"type": "Feature", "properties": {"OA11CD": "E00000001"}, "geometry": {"type": "Polygon", "coordinates": [[[-0.1, 51.5], [-0.09, 51.5], [-0.09, 51.51], [-0.1, 51.51], [-0.1, 51.5]]]}},
+			{"type": "Feature", "properties": {"OA11CD": "E00000002"}, "geometry": {"type": "MultiPolygon", "coordinates": [[[[-0.09, 51.5], [-0.05, 51.5], [-0.05, 51.51], [-0.09, 51.51], [-0.09, 51.5]]]]}},
+			{"type": "Feature", "properties": {"OA11CD": "E00000003"}, "geometry": {"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [1, 1], [0, 0]]]}},
+			{"type": "Feature", "properties": {}, "geometry": null}
+		]
+	}`
+
+	err := os.WriteFile("boundaries.geojson", []byte(geoJSON), 0o600)
+	if err != nil {
+		t.Fatalf("Failed to write test data: %v", err)
+	}
+	defer os.Remove("boundaries.geojson")
+
+	bi, err := getBoundaries(&config.Config{BoundaryDataFile: "boundaries.geojson", BoundaryCodeProperty: "OA11CD"}, boundaryAreas())
+	assert.Nil(t, err)
+
+	// E00000003 is not one of the areas
+	assert.Equal(t, 2, bi.Len())
+	assert.Equal(t, "E00000001", bi.Locate(51.505, -0.095).OutputAreaCode)
+	assert.Equal(t, "E00000002", bi.Locate(51.505, -0.06).OutputAreaCode)
+}
+
+func TestGetBoundariesShapefile(t *testing.T) {
+	writeShapefile(t, "boundaries", "OA11CD", map[string][][2]float64{
+		"E00000001": square(-0.1, 51.5, -0.09, 51.51),
+		"E00000002": square(-0.09, 51.5, -0.05, 51.51),
+	}, []string{"E00000001", "E00000002"})
+	defer os.Remove("boundaries.shp")
+	defer os.Remove("boundaries.dbf")
+
+	bi, err := getBoundaries(&config.Config{BoundaryDataFile: "boundaries.shp", BoundaryCodeProperty: "OA11CD"}, boundaryAreas())
+	assert.Nil(t, err)
+
+	assert.Equal(t, 2, bi.Len())
+	assert.Equal(t, "E00000001", bi.Locate(51.505, -0.095).OutputAreaCode)
+	assert.Equal(t, "E00000002", bi.Locate(51.505, -0.06).OutputAreaCode)
+
+	_, err = getBoundaries(&config.Config{BoundaryDataFile: "boundaries.shp", BoundaryCodeProperty: "OA21CD"}, boundaryAreas())
+	assert.NotNil(t, err)
+}
+
+func TestGetBoundariesBritishNationalGrid(t *testing.T) {
+	// two 1km squares of the grid near Holborn, whose centres are located
+	// after converting to longitude and latitude
+	first, second := square(530000, 181000, 531000, 182000), square(531000, 181000, 532000, 182000)
+	firstLat, firstLon := geo.OSGBToWGS84(530500, 181500)
+	secondLat, secondLon := geo.OSGBToWGS84(531500, 181500)
+
+	geoJSON := func(crs string) string {
+		return `{
+			"type": "FeatureCollection",` + crs + `
+			"features": [
+				{"type": "Feature", "properties": {"OA11CD": "E00000001"}, "geometry": {"type": "Polygon", "coordinates": [[[530000, 181000], [531000, 181000], [531000, 182000], [530000, 182000], [530000, 181000]]]}},
+				{"type": "Feature", "properties": {"OA11CD": "E00000002"}, "geometry": {"type": "Polygon", "coordinates": [[[531000, 181000], [532000, 181000], [532000, 182000], [531000, 182000], [531000, 181000]]]}}
+			]
+		}`
+	}
+
+	tests := []struct {
+		name, file, crs string
+	}{
+		{name: "GeoJSON naming its crs", file: "boundaries.geojson", crs: `"crs": {"type": "name", "properties": {"name": "urn:ogc:def:crs:EPSG::27700"}},`},
+		{name: "GeoJSON without a crs", file: "boundaries.geojson"},
+		{name: "shapefile with a .prj", file: "boundaries.shp", crs: `PROJCS["British_National_Grid",GEOGCS["GCS_OSGB_1936",DATUM["D_OSGB_1936",SPHEROID["Airy_1830",6377563.396,299.3249646]]]]`},
+		{name: "shapefile without a .prj", file: "boundaries.shp"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.file == "boundaries.shp" {
+				writeShapefile(t, "boundaries", "OA11CD", map[string][][2]float64{"E00000001": first, "E00000002": second}, []string{"E00000001", "E00000002"})
+				defer os.Remove("boundaries.shp")
+				defer os.Remove("boundaries.dbf")
+
+				if tt.crs != "" {
+					if err := os.WriteFile("boundaries.prj", []byte(tt.crs), 0o600); err != nil {
+						t.Fatalf("Failed to write test data: %v", err)
+					}
+					defer os.Remove("boundaries.prj")
+				}
+			} else {
+				if err := os.WriteFile(tt.file, []byte(geoJSON(tt.crs)), 0o600); err != nil {
+					t.Fatalf("Failed to write test data: %v", err)
+				}
+				defer os.Remove(tt.file)
+			}
+
+			bi, err := getBoundaries(&config.Config{BoundaryDataFile: tt.file, BoundaryCodeProperty: "OA11CD"}, boundaryAreas())
+			assert.Nil(t, err)
+
+			assert.Equal(t, 2, bi.Len())
+			assert.Equal(t, "E00000001", bi.Locate(firstLat, firstLon).OutputAreaCode)
+			assert.Equal(t, "E00000002", bi.Locate(secondLat, secondLon).OutputAreaCode)
+		})
+	}
+}
+
+func TestGetBoundariesUnsupportedProjection(t *testing.T) {
+	geoJSON := `{"type": "FeatureCollection", "crs": {"type": "name", "properties": {"name": "urn:ogc:def:crs:EPSG::3857"}}, "features": []}`
+
+	err := os.WriteFile("boundaries.geojson", []byte(geoJSON), 0o600)
+	if err != nil {
+		t.Fatalf("Failed to write test data: %v", err)
+	}
+	defer os.Remove("boundaries.geojson")
+
+	_, err = getBoundaries(&config.Config{BoundaryDataFile: "boundaries.geojson", BoundaryCodeProperty: "OA11CD"}, boundaryAreas())
+	assert.NotNil(t, err)
+
+	writeShapefile(t, "boundaries", "OA11CD", map[string][][2]float64{"E00000001": square(0, 0, 1, 1)}, []string{"E00000001"})
+	defer os.Remove("boundaries.shp")
+	defer os.Remove("boundaries.dbf")
+
+	err = os.WriteFile("boundaries.prj", []byte(`PROJCS["WGS_1984_Web_Mercator_Auxiliary_Sphere",GEOGCS["GCS_WGS_1984"]]`), 0o600)
+	if err != nil {
+		t.Fatalf("Failed to write test data: %v", err)
+	}
+	defer os.Remove("boundaries.prj")
+
+	_, err = getBoundaries(&config.Config{BoundaryDataFile: "boundaries.shp", BoundaryCodeProperty: "OA11CD"}, boundaryAreas())
+	assert.NotNil(t, err)
+}
+
+func TestGetBoundariesUnsupportedFile(t *testing.T) {
+	_, err := getBoundaries(&config.Config{BoundaryDataFile: "boundaries.kml"}, boundaryAreas())
+	assert.NotNil(t, err)
+}
+
+// writeShapefile writes name.shp holding a polygon for each code, and
+// name.dbf holding the codes in a single column
+func writeShapefile(t *testing.T, name, column string, rings map[string][][2]float64, codes []string) {
+	t.Helper()
+
+	shp := make([]byte, 100)
+	binary.BigEndian.PutUint32(shp[0:4], 9994)
+	binary.LittleEndian.PutUint32(shp[28:32], 1000)
+	binary.LittleEndian.PutUint32(shp[32:36], shapePolygon)
+
+	for i, code := range codes {
+		ring := rings[code]
+
+		content := make([]byte, 48+len(ring)*16)
+		binary.LittleEndian.PutUint32(content[0:4], shapePolygon)
+		binary.LittleEndian.PutUint32(content[36:40], 1)
+		binary.LittleEndian.PutUint32(content[40:44], uint32(len(ring)))
+
+		for p, point := range ring {
+			binary.LittleEndian.PutUint64(content[48+p*16:], math.Float64bits(point[0]))
+			binary.LittleEndian.PutUint64(content[56+p*16:], math.Float64bits(point[1]))
+		}
+
+		header := make([]byte, 8)
+		binary.BigEndian.PutUint32(header[0:4], uint32(i+1))
+		binary.BigEndian.PutUint32(header[4:8], uint32(len(content)/2))
+
+		shp = append(shp, header...)
+		shp = append(shp, content...)
+	}
+
+	binary.BigEndian.PutUint32(shp[24:28], uint32(len(shp)/2))
+
+	const width = 9
+
+	dbf := make([]byte, 65)
+	binary.LittleEndian.PutUint32(dbf[4:8], uint32(len(codes)))
+	binary.LittleEndian.PutUint16(dbf[8:10], 65)
+	binary.LittleEndian.PutUint16(dbf[10:12], width+1)
+	copy(dbf[32:43], column)
+	dbf[43] = 'C'
+	dbf[48] = width
+	dbf[64] = 0x0D
+
+	for _, code := range codes {
+		dbf = append(dbf, ' ')
+		dbf = append(dbf, code...)
+	}
+
+	if err := os.WriteFile(name+".shp", shp, 0o600); err != nil {
+		t.Fatalf("Failed to write test data: %v", err)
+	}
+
+	if err := os.WriteFile(name+".dbf", dbf, 0o600); err != nil {
+		t.Fatalf("Failed to write test data: %v", err)
+	}
+}
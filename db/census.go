@@ -0,0 +1,68 @@
+package db
+
+import (
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/config"
+	"github.com/gocarina/gocsv"
+)
+
+// The kinds of Census 2021 code
+const (
+	CensusTable    = "table"
+	CensusVariable = "variable"
+)
+
+// censusTableRe matches how a Census 2021 table code looks e.g. TS001, RM052
+// or TS007A
+var censusTableRe = regexp.MustCompile(`^[A-Za-z]{2}\d{3}[A-Za-z]?$`)
+
+// CensusCode is a Census 2021 table, such as TS001, or variable, such as
+// hh_size, and its title
+type CensusCode struct {
+	Code  string `csv:"Code"`
+	Title string `csv:"Title"`
+}
+
+// Type returns whether the code is of a table or a variable, going by how it
+// looks
+func (c CensusCode) Type() string {
+	if censusTableRe.MatchString(c.Code) {
+		return CensusTable
+	}
+
+	return CensusVariable
+}
+
+// NewCensusIndex indexes codes case insensitively, as variables are written
+// in lower case and tables in upper case
+func NewCensusIndex(codes []CensusCode) *Index[CensusCode] {
+	return NewIndex(codes, censusKey)
+}
+
+func censusKey(code CensusCode) string {
+	return strings.ToLower(code.Code)
+}
+
+func getCensusCodes(cfg *config.Config) ([]CensusCode, error) {
+	file, err := os.Open(cfg.CensusDataFile)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	codes := []CensusCode{}
+
+	if err := gocsv.UnmarshalFile(file, &codes); err != nil {
+		return nil, err
+	}
+
+	for i := range codes {
+		codes[i].Code = strings.TrimSpace(codes[i].Code)
+	}
+
+	return codes, nil
+}
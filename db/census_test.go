@@ -0,0 +1,37 @@
+package db
+
+import (
+	"os"
+	"testing"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCensusCodes(t *testing.T) {
+	err := os.WriteFile("census.csv", []byte("Code,Title\n TS001 ,Number of usual residents in households and communal establishments\nhh_size,Household size\n"), 0o600)
+	if err != nil {
+		t.Fatalf("Failed to write test data: %v", err)
+	}
+	defer os.Remove("census.csv")
+
+	codes, err := getCensusCodes(&config.Config{CensusDataFile: "census.csv"})
+	assert.Nil(t, err)
+
+	assert.Equal(t, []CensusCode{
+		{Code: "TS001", Title: "Number of usual residents in households and communal establishments"},
+		{Code: "hh_size", Title: "Household size"},
+	}, codes)
+
+	idx := NewCensusIndex(codes)
+	assert.Len(t, idx.Get("ts001"), 1)
+	assert.Len(t, idx.Get("hh_size"), 1)
+	assert.Empty(t, idx.Get("TS001"))
+}
+
+func TestCensusCodeType(t *testing.T) {
+	assert.Equal(t, CensusTable, CensusCode{Code: "TS001"}.Type())
+	assert.Equal(t, CensusTable, CensusCode{Code: "TS007A"}.Type())
+	assert.Equal(t, CensusVariable, CensusCode{Code: "hh_size"}.Type())
+	assert.Equal(t, CensusVariable, CensusCode{Code: "sex"}.Type())
+}
@@ -0,0 +1,64 @@
+package db
+
+import (
+	"os"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/config"
+	"github.com/gocarina/gocsv"
+)
+
+// Centroid is a row of the ONS output area population weighted centroids
+type Centroid struct {
+	OutputAreaCode string  `csv:"OA11CD"`
+	Latitude       float64 `csv:"LAT"`
+	Longitude      float64 `csv:"LONG"`
+}
+
+func getCentroids(cfg *config.Config) ([]Centroid, error) {
+	file, err := os.Open(cfg.CentroidDataFile)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	cr := []Centroid{}
+
+	if err := gocsv.UnmarshalFile(file, &cr); err != nil {
+		return nil, err
+	}
+
+	return cr, nil
+}
+
+func loadCentroids(cfg *config.Config, areas []Area) error {
+	centroids, err := getCentroids(cfg)
+	if err != nil {
+		return err
+	}
+
+	addCentroids(areas, centroids)
+
+	return nil
+}
+
+// addCentroids sets the location of each area found in centroids
+func addCentroids(areas []Area, centroids []Centroid) {
+	byOutputArea := make(map[string]*Centroid, len(centroids))
+	for i := range centroids {
+		byOutputArea[centroids[i].OutputAreaCode] = &centroids[i]
+	}
+
+	for i := range areas {
+		if c, found := byOutputArea[areas[i].OutputAreaCode]; found {
+			areas[i].Latitude = c.Latitude
+			areas[i].Longitude = c.Longitude
+		}
+	}
+}
+
+// HasCentroid reports whether the location of the area is known. No output
+// area is anywhere near 0,0 so it is safe to treat it as unset.
+func (a *Area) HasCentroid() bool {
+	return a.Latitude != 0 || a.Longitude != 0
+}
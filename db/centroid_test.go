@@ -0,0 +1,30 @@
+package db
+
+import (
+	"os"
+	"testing"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadCentroids(t *testing.T) {
+	err := os.WriteFile("centroids.csv", []byte("OA11CD,LAT,LONG\nE00000001,51.52,-0.09\n"), 0o600)
+	if err != nil {
+		t.Fatalf("Failed to write test data: %v", err)
+	}
+	defer os.Remove("centroids.csv")
+
+	areas := []Area{
+		{OutputAreaCode: "E00000001"},
+		{OutputAreaCode: "E00000002"},
+	}
+
+	err = loadCentroids(&config.Config{CentroidDataFile: "centroids.csv"}, areas)
+	assert.Nil(t, err)
+
+	assert.True(t, areas[0].HasCentroid())
+	assert.Equal(t, 51.52, areas[0].Latitude)
+	assert.Equal(t, -0.09, areas[0].Longitude)
+	assert.False(t, areas[1].HasCentroid())
+}
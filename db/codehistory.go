@@ -0,0 +1,111 @@
+package db
+
+import (
+	"os"
+	"strings"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/config"
+	"github.com/gocarina/gocsv"
+)
+
+// CodeChange is a row of the changes table of the ONS Code History Database,
+// recording a GSS code retired in favour of a successor, such as the E07
+// districts merged into a new E06 unitary authority. A code split between
+// several successors has a row for each.
+type CodeChange struct {
+	Successor     string `csv:"GEOGCD"`
+	SuccessorName string `csv:"GEOGNM"`
+	Code          string `csv:"GEOGCD_P"`
+	Name          string `csv:"GEOGNM_P"`
+	Date          string `csv:"OPER_DATE"`
+}
+
+// CodeHistory finds the successors of retired GSS codes
+type CodeHistory struct {
+	index *Index[CodeChange]
+}
+
+// NewCodeHistory indexes changes by the code retired
+func NewCodeHistory(changes []CodeChange) *CodeHistory {
+	return &CodeHistory{
+		index: NewIndex(changes, func(c CodeChange) string { return c.Code }),
+	}
+}
+
+// Len returns the number of changes held in the history
+func (ch *CodeHistory) Len() int {
+	if ch == nil {
+		return 0
+	}
+
+	return ch.index.Len()
+}
+
+// Changes returns the changes that retired code, or nothing if it is current
+func (ch *CodeHistory) Changes(code string) []CodeChange {
+	if ch == nil {
+		return nil
+	}
+
+	return ch.index.Get(code)
+}
+
+// Current returns the codes in use that replaced code, following it through
+// every later change, or nothing if code was never retired
+func (ch *CodeHistory) Current(code string) []string {
+	var current []string
+
+	visited := map[string]bool{code: true}
+
+	var follow func(code string)
+
+	follow = func(code string) {
+		for _, change := range ch.Changes(code) {
+			if visited[change.Successor] {
+				continue
+			}
+
+			visited[change.Successor] = true
+
+			if len(ch.Changes(change.Successor)) > 0 {
+				follow(change.Successor)
+			} else {
+				current = append(current, change.Successor)
+			}
+		}
+	}
+
+	follow(code)
+
+	return current
+}
+
+// getCodeChanges reads the code history file, leaving out the changes of name
+// that kept the same code
+func getCodeChanges(cfg *config.Config) ([]CodeChange, error) {
+	file, err := os.Open(cfg.CodeHistoryFile)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	rows := []CodeChange{}
+
+	if err := gocsv.UnmarshalFile(file, &rows); err != nil {
+		return nil, err
+	}
+
+	changes := make([]CodeChange, 0, len(rows))
+
+	for _, change := range rows {
+		change.Code = strings.ToUpper(strings.TrimSpace(change.Code))
+		change.Successor = strings.ToUpper(strings.TrimSpace(change.Successor))
+
+		if change.Code != "" && change.Successor != "" && change.Code != change.Successor {
+			changes = append(changes, change)
+		}
+	}
+
+	return changes, nil
+}
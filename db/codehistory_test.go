@@ -0,0 +1,46 @@
+package db
+
+import (
+	"os"
+	"testing"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCodeChanges(t *testing.T) {
+	err := os.WriteFile("code_history.csv", []byte("GEOGCD,GEOGNM,GEOGCD_P,GEOGNM_P,OPER_DATE\nE06000061,North Northamptonshire, e07000150 ,Corby,01/04/2021\nE06000061,North Northamptonshire,E07000152,East Northamptonshire,01/04/2021\nE06000061,North Northamptonshire Council,E06000061,North Northamptonshire,01/05/2021\n"), 0o600)
+	if err != nil {
+		t.Fatalf("Failed to write test data: %v", err)
+	}
+	defer os.Remove("code_history.csv")
+
+	changes, err := getCodeChanges(&config.Config{CodeHistoryFile: "code_history.csv"})
+	assert.Nil(t, err)
+
+	assert.Equal(t, []CodeChange{
+		{Successor: "E06000061", SuccessorName: "North Northamptonshire", Code: "E07000150", Name: "Corby", Date: "01/04/2021"},
+		{Successor: "E06000061", SuccessorName: "North Northamptonshire", Code: "E07000152", Name: "East Northamptonshire", Date: "01/04/2021"},
+	}, changes)
+}
+
+func TestCodeHistoryCurrent(t *testing.T) {
+	history := NewCodeHistory([]CodeChange{
+		{Code: "A", Successor: "B"},
+		{Code: "B", Successor: "C"},
+		{Code: "B", Successor: "D"},
+		{Code: "X", Successor: "Y"},
+		{Code: "Y", Successor: "X"},
+	})
+
+	assert.Equal(t, 5, history.Len())
+	assert.Equal(t, []string{"C", "D"}, history.Current("A"))
+	assert.Equal(t, []string{"C", "D"}, history.Current("B"))
+	assert.Empty(t, history.Current("C"))
+	assert.Empty(t, history.Current("X"))
+	assert.Len(t, history.Changes("B"), 2)
+
+	var missing *CodeHistory
+	assert.Zero(t, missing.Len())
+	assert.Empty(t, missing.Current("A"))
+}
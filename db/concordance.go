@@ -0,0 +1,87 @@
+package db
+
+import (
+	"os"
+	"strings"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/config"
+	"github.com/gocarina/gocsv"
+)
+
+// IndustryConcordance links a SIC 2007 code to its equivalent in another
+// classification, such as NACE Rev.2, ISIC Rev.4 or SIC 2003. A code can have
+// more than one equivalent, and be the equivalent of more than one code.
+type IndustryConcordance struct {
+	SIC2007 string `csv:"SIC2007"`
+	System  string `csv:"System"`
+	Code    string `csv:"Code"`
+	Name    string `csv:"Name"`
+}
+
+// ConcordanceIndex finds the equivalents of SIC 2007 codes in other
+// classifications, and the SIC 2007 codes that codes of other classifications
+// map to
+type ConcordanceIndex struct {
+	bySIC2007 *Index[IndustryConcordance]
+	byCode    *Index[IndustryConcordance]
+}
+
+// NewConcordanceIndex indexes concordances both ways
+func NewConcordanceIndex(concordances []IndustryConcordance) *ConcordanceIndex {
+	return &ConcordanceIndex{
+		bySIC2007: NewIndex(concordances, func(c IndustryConcordance) string { return c.SIC2007 + "/" + c.System }),
+		byCode:    NewIndex(concordances, func(c IndustryConcordance) string { return c.System + "/" + c.Code }),
+	}
+}
+
+// Len returns the number of concordances held in the index
+func (ci *ConcordanceIndex) Len() int {
+	if ci == nil {
+		return 0
+	}
+
+	return ci.bySIC2007.Len()
+}
+
+// Equivalents returns the codes of system equivalent to the SIC 2007 code
+func (ci *ConcordanceIndex) Equivalents(sic2007, system string) []IndustryConcordance {
+	if ci == nil {
+		return nil
+	}
+
+	return ci.bySIC2007.Get(sic2007 + "/" + system)
+}
+
+// ToSIC2007 returns the concordances of the code of system, which give the
+// SIC 2007 codes it maps to
+func (ci *ConcordanceIndex) ToSIC2007(system, code string) []IndustryConcordance {
+	if ci == nil {
+		return nil
+	}
+
+	return ci.byCode.Get(system + "/" + code)
+}
+
+func getIndustryConcordances(cfg *config.Config) ([]IndustryConcordance, error) {
+	file, err := os.Open(cfg.IndustryConcordanceFile)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	concordances := []IndustryConcordance{}
+
+	if err := gocsv.UnmarshalFile(file, &concordances); err != nil {
+		return nil, err
+	}
+
+	for i := range concordances {
+		concordances[i].SIC2007 = strings.TrimSpace(concordances[i].SIC2007)
+		concordances[i].System = strings.ToLower(strings.TrimSpace(concordances[i].System))
+		concordances[i].Code = strings.TrimSpace(concordances[i].Code)
+		concordances[i].Name = strings.TrimSpace(concordances[i].Name)
+	}
+
+	return concordances, nil
+}
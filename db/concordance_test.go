@@ -0,0 +1,38 @@
+package db
+
+import (
+	"os"
+	"testing"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetIndustryConcordances(t *testing.T) {
+	err := os.WriteFile("concordance.csv", []byte("SIC2007,System,Code,Name\n01110, NACE ,A01.1,Growing of non-perennial crops\n01110,sic2003,01110, Growing of cereals and other crops\n01120,sic2003,01110,Growing of cereals and other crops\n"), 0o600)
+	if err != nil {
+		t.Fatalf("Failed to write test data: %v", err)
+	}
+	defer os.Remove("concordance.csv")
+
+	concordances, err := getIndustryConcordances(&config.Config{IndustryConcordanceFile: "concordance.csv"})
+	assert.Nil(t, err)
+
+	assert.Equal(t, []IndustryConcordance{
+		{SIC2007: "01110", System: "nace", Code: "A01.1", Name: "Growing of non-perennial crops"},
+		{SIC2007: "01110", System: "sic2003", Code: "01110", Name: "Growing of cereals and other crops"},
+		{SIC2007: "01120", System: "sic2003", Code: "01110", Name: "Growing of cereals and other crops"},
+	}, concordances)
+
+	idx := NewConcordanceIndex(concordances)
+	assert.Equal(t, 3, idx.Len())
+	assert.Equal(t, concordances[:1], idx.Equivalents("01110", "nace"))
+	assert.Empty(t, idx.Equivalents("01110", "isic"))
+	assert.Equal(t, concordances[1:], idx.ToSIC2007("sic2003", "01110"))
+	assert.Empty(t, idx.ToSIC2007("nace", "01110"))
+
+	var missing *ConcordanceIndex
+	assert.Zero(t, missing.Len())
+	assert.Empty(t, missing.Equivalents("01110", "nace"))
+	assert.Empty(t, missing.ToSIC2007("sic2003", "01110"))
+}
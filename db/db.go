@@ -4,16 +4,267 @@ import (
 	"context"
 
 	"github.com/ONSdigital/dp-search-scrubber-api/config"
+	"github.com/ONSdigital/dp-search-scrubber-api/text"
 	"github.com/ONSdigital/log.go/v2/log"
-	"github.com/alediaferia/prefixmap"
 )
 
 type ScrubberDB struct {
-	AreasPFM      *prefixmap.PrefixMap
-	IndustriesPFM *prefixmap.PrefixMap
+	Areas         *Index[*Area]
+	LSOAs         *Index[*Area]
+	MSOAs         *Index[*Area]
+	AreaNames     *PhraseIndex[AreaName]
+	AreaCodes     *Index[AreaName]
+	CodeHistory   *CodeHistory
+	Locations     *PointIndex
+	Boundaries    *BoundaryIndex
+	Industries    *Index[Industry]
+	IndustryNames *WordIndex[IndustryName]
+	Synonyms      *PhraseIndex[IndustrySynonym]
+	Concordances  *ConcordanceIndex
+	StopWords     text.StopWords
+	TimeSeries    *Index[TimeSeries]
+	Census        *Index[CensusCode]
+	// Occupations are the groups of SOC 2020 by code, and OccupationTitles
+	// its unit groups by title
+	Occupations      *Index[Occupation]
+	OccupationTitles *PhraseIndex[Occupation]
 }
 
 func LoadCsvData(ctx context.Context, cfg *config.Config) ScrubberDB {
+	areaData, industryData, indexes := loadData(ctx, cfg)
+
+	internAreas(areaData)
+
+	if indexes == nil {
+		built := buildAreaIndexes(areaData)
+		indexes = &built
+	}
+
+	// the names of areas and industries are indexed without the stop words,
+	// so they are loaded first
+	stopWords := text.DefaultStopWords()
+
+	if cfg.StopWordFile != "" {
+		words, err := getStopWords(cfg)
+		if err != nil {
+			log.Error(ctx, "Error loading stop word data, using the default stop words: ", err)
+		} else {
+			stopWords = NewStopWords(words)
+			log.Info(ctx, "Successfully loaded stop word data", log.Data{"stop_words": len(words)})
+		}
+	}
+
+	sdb := newScrubberDB(areaData, industryData, stopWords, *indexes)
+
+	// boundaries are too large to be worth holding in the snapshot, and
+	// are only needed when searching by location
+	if cfg.BoundaryDataFile != "" {
+		boundaries, err := getBoundaries(cfg, sdb.Areas)
+		if err != nil {
+			log.Error(ctx, "Error loading Area boundary data: ", err)
+		} else {
+			sdb.Boundaries = boundaries
+			log.Info(ctx, "Successfully loaded Area boundary data", log.Data{"boundaries": boundaries.Len()})
+		}
+	}
+
+	if cfg.PlaceDataFile != "" {
+		places, err := getPlaces(cfg)
+		if err != nil {
+			log.Error(ctx, "Error loading place data: ", err)
+		} else {
+			added := sdb.AddPlaces(areaData, places)
+			log.Info(ctx, "Successfully loaded place data", log.Data{"places": added, "left_out": len(places) - added})
+		}
+	}
+
+	if cfg.CodeHistoryFile != "" {
+		changes, err := getCodeChanges(cfg)
+		if err != nil {
+			log.Error(ctx, "Error loading code history data: ", err)
+		} else {
+			sdb.CodeHistory = NewCodeHistory(changes)
+			log.Info(ctx, "Successfully loaded code history data", log.Data{"changes": sdb.CodeHistory.Len()})
+		}
+	}
+
+	if cfg.IndustrySynonymFile != "" {
+		synonyms, err := getIndustrySynonyms(cfg)
+		if err != nil {
+			log.Error(ctx, "Error loading Industry synonym data: ", err)
+		} else {
+			sdb.Synonyms = NewSynonymIndex(synonyms, sdb.StopWords)
+			log.Info(ctx, "Successfully loaded Industry synonym data", log.Data{"synonyms": sdb.Synonyms.Len()})
+		}
+	}
+
+	if cfg.IndustryConcordanceFile != "" {
+		concordances, err := getIndustryConcordances(cfg)
+		if err != nil {
+			log.Error(ctx, "Error loading Industry concordance data: ", err)
+		} else {
+			sdb.Concordances = NewConcordanceIndex(concordances)
+			log.Info(ctx, "Successfully loaded Industry concordance data", log.Data{"concordances": sdb.Concordances.Len()})
+		}
+	}
+
+	if cfg.TimeSeriesDataFile != "" {
+		series, err := getTimeSeries(cfg)
+		if err != nil {
+			log.Error(ctx, "Error loading time series data: ", err)
+		} else {
+			sdb.TimeSeries = NewTimeSeriesIndex(series)
+			log.Info(ctx, "Successfully loaded time series data", log.Data{"time_series": sdb.TimeSeries.Len()})
+		}
+	}
+
+	if cfg.CensusDataFile != "" {
+		codes, err := getCensusCodes(cfg)
+		if err != nil {
+			log.Error(ctx, "Error loading Census code data: ", err)
+		} else {
+			sdb.Census = NewCensusIndex(codes)
+			log.Info(ctx, "Successfully loaded Census code data", log.Data{"census_codes": sdb.Census.Len()})
+		}
+	}
+
+	if cfg.OccupationDataFile != "" {
+		occupations, err := getOccupations(cfg)
+		if err != nil {
+			log.Error(ctx, "Error loading Occupation data: ", err)
+		} else {
+			sdb.Occupations = NewOccupationIndex(occupations)
+			sdb.OccupationTitles = NewOccupationTitleIndex(occupations, sdb.StopWords)
+			log.Info(ctx, "Successfully loaded Occupation data", log.Data{"occupations": sdb.Occupations.Len()})
+		}
+	}
+
+	return sdb
+}
+
+// NewScrubberDB indexes areas by their output area, LSOA and MSOA codes, by
+// the names and codes of their local authorities and regions and by location,
+// and industries by SIC code and by the words of their names, leaving out
+// stopWords, or the default stop words if nil. No time series, Census codes,
+// occupations or code history are held until they are loaded.
+func NewScrubberDB(areas []Area, industries []Industry, stopWords text.StopWords) ScrubberDB {
+	return newScrubberDB(areas, industries, stopWords, buildAreaIndexes(areas))
+}
+
+// areaIndexes are the indexes of areas that take longest to build, which a
+// snapshot holds ready built
+type areaIndexes struct {
+	areas, lsoas, msoas *Index[*Area]
+	names               []AreaName
+}
+
+func buildAreaIndexes(areas []Area) areaIndexes {
+	return areaIndexes{
+		areas: indexAreas(areas, outputAreaKey),
+		lsoas: indexAreas(areas, lsoaKey),
+		msoas: indexAreas(areas, msoaKey),
+		names: areaNames(areas),
+	}
+}
+
+func newScrubberDB(areas []Area, industries []Industry, stopWords text.StopWords, indexes areaIndexes) ScrubberDB {
+	if stopWords == nil {
+		stopWords = text.DefaultStopWords()
+	}
+
+	return ScrubberDB{
+		Areas:         indexes.areas,
+		LSOAs:         indexes.lsoas,
+		MSOAs:         indexes.msoas,
+		AreaNames:     NewPhraseIndex(indexes.names, areaNameKey, stopWords),
+		AreaCodes:     NewIndex(indexes.names, areaCodeKey),
+		Locations:     NewPointIndex(areas),
+		Industries:    NewIndex(industries, industryKey),
+		IndustryNames: NewWordIndex(industryNames(industries), industryNameKey, stopWords),
+		StopWords:     stopWords,
+		TimeSeries:    NewTimeSeriesIndex(nil),
+		Census:        NewCensusIndex(nil),
+		Occupations:   NewOccupationIndex(nil),
+	}
+}
+
+// AreasByCode returns the output areas identified by code, which may be the
+// code of an output area, LSOA or MSOA
+func (sdb ScrubberDB) AreasByCode(code string) []*Area {
+	for _, idx := range []*Index[*Area]{sdb.Areas, sdb.LSOAs, sdb.MSOAs} {
+		if areas := idx.Get(code); len(areas) > 0 {
+			return areas
+		}
+	}
+
+	return nil
+}
+
+// Locate returns the output area containing the point when boundaries are
+// loaded, or otherwise the output area with the nearest centroid. A point
+// outside every boundary, such as one just off the coast, also falls back to
+// the nearest centroid.
+func (sdb ScrubberDB) Locate(lat, lon float64) *Area {
+	if area := sdb.Boundaries.Locate(lat, lon); area != nil {
+		return area
+	}
+
+	return sdb.Locations.Nearest(lat, lon)
+}
+
+// indexAreas indexes every area that has a key, sharing the areas themselves
+// between all of the indexes built over them
+func indexAreas(areas []Area, key func(*Area) string) *Index[*Area] {
+	keyed := make([]*Area, 0, len(areas))
+
+	for i := range areas {
+		if key(&areas[i]) != "" {
+			keyed = append(keyed, &areas[i])
+		}
+	}
+
+	return NewIndex(keyed, key)
+}
+
+func outputAreaKey(area *Area) string {
+	return area.OutputAreaCode
+}
+
+func lsoaKey(area *Area) string {
+	return area.LSOACode
+}
+
+func msoaKey(area *Area) string {
+	return area.MSOACode
+}
+
+func areaCodeKey(name AreaName) string {
+	return name.Code
+}
+
+func industryKey(industry Industry) string {
+	return industry.Code
+}
+
+// loadData returns the area and industry data, and the indexes of the areas,
+// from the snapshot when it is present and up to date, falling back to
+// parsing the CSV files. The indexes are nil when the data comes from the CSV
+// files, as they are still to be built.
+func loadData(ctx context.Context, cfg *config.Config) ([]Area, []Industry, *areaIndexes) {
+	if cfg.SnapshotFile != "" {
+		snap, err := loadSnapshot(cfg)
+		if err == nil {
+			var indexes *areaIndexes
+
+			if indexes, err = snap.areaIndexes(); err == nil {
+				log.Info(ctx, "Successfully loaded data from snapshot", log.Data{"snapshot_file": cfg.SnapshotFile})
+				return snap.Areas, snap.Industries, indexes
+			}
+		}
+
+		log.Info(ctx, "Unable to use snapshot, loading data from CSV files", log.Data{"snapshot_file": cfg.SnapshotFile, "reason": err.Error()})
+	}
+
 	// gets area data
 	areaData, err := getArea(cfg)
 	if err != nil {
@@ -22,6 +273,17 @@ func LoadCsvData(ctx context.Context, cfg *config.Config) ScrubberDB {
 		log.Info(ctx, "Successfully loaded Area data")
 	}
 
+	areaData = addOptionalAreas(ctx, cfg, areaData)
+
+	// adds detail from the optional area files
+	for _, extra := range areaExtras(cfg) {
+		if err := extra.add(cfg, areaData); err != nil {
+			log.Error(ctx, "Error loading Area "+extra.name+" data: ", err)
+		} else {
+			log.Info(ctx, "Successfully loaded Area "+extra.name+" data")
+		}
+	}
+
 	// gets industry data
 	industryData, err := getIndustry(cfg)
 	if err != nil {
@@ -30,20 +292,13 @@ func LoadCsvData(ctx context.Context, cfg *config.Config) ScrubberDB {
 		log.Info(ctx, "Successfully loaded Industry data")
 	}
 
-	// creates a new area prefixmap and populates it
-	areasMap := prefixmap.New()
-	for _, area := range areaData {
-		areasMap.Insert(area.OutputAreaCode, area)
+	if cfg.IndustryWelshDataFile != "" {
+		if err := loadWelshIndustries(cfg, industryData); err != nil {
+			log.Error(ctx, "Error loading Industry Welsh name data: ", err)
+		} else {
+			log.Info(ctx, "Successfully loaded Industry Welsh name data")
+		}
 	}
 
-	// creates a new industry prefixmap and populates it
-	industryMap := prefixmap.New()
-	for _, industry := range industryData {
-		industryMap.Insert(industry.Code, industry)
-	}
-
-	return ScrubberDB{
-		AreasPFM:      areasMap,
-		IndustriesPFM: industryMap,
-	}
+	return areaData, industryData, nil
 }
@@ -47,14 +47,13 @@ func TestLoadCsvData(t *testing.T) {
 	}
 
 	// check if the function returns the expected result
-	assert.NotNil(t, sr.AreasPFM)
-	assert.NotNil(t, sr.IndustriesPFM)
+	assert.NotNil(t, sr.Areas)
+	assert.NotNil(t, sr.Industries)
 
 	for _, e := range expectedAreas {
-		matchingRecords := sr.AreasPFM.GetByPrefix(e.OutputAreaCode)
+		matchingRecords := sr.Areas.GetByPrefix(e.OutputAreaCode)
 		assert.NotEqual(t, len(matchingRecords), 0)
-		for _, mr := range matchingRecords {
-			area := mr.(Area)
+		for _, area := range matchingRecords {
 			assert.Equal(t, area.RegionCode, e.RegionCode)
 			assert.Equal(t, area.LocalAuthorityCode, e.LocalAuthorityCode)
 			assert.Equal(t, area.LAName, e.LAName)
@@ -78,10 +77,9 @@ func TestLoadCsvData(t *testing.T) {
 	}
 
 	for _, e := range expectedIndustries {
-		matchingRecords := sr.IndustriesPFM.GetByPrefix(e.code)
+		matchingRecords := sr.Industries.GetByPrefix(e.code)
 		assert.NotEqual(t, len(matchingRecords), 0)
-		for _, mr := range matchingRecords {
-			industry := mr.(Industry)
+		for _, industry := range matchingRecords {
 			assert.Equal(t, industry.Code, e.code)
 			assert.Equal(t, industry.Name, e.name)
 		}
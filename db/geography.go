@@ -0,0 +1,63 @@
+package db
+
+import "strings"
+
+// Levels of the geography hierarchy, from smallest to largest
+const (
+	LevelOutputArea     = "output_area"
+	LevelLSOA           = "lsoa"
+	LevelMSOA           = "msoa"
+	LevelLocalAuthority = "local_authority"
+	LevelRegion         = "region"
+	LevelCountry        = "country"
+)
+
+// LevelSupergroup is the output area classification supergroup of an area,
+// which sits outside of the geography hierarchy
+const LevelSupergroup = "supergroup"
+
+// Geography is a single area at one level of the geography hierarchy
+type Geography struct {
+	Level     string
+	Code      string
+	Name      string
+	WelshName string
+}
+
+// NameIn returns the name of the geography in language, falling back to
+// English when there is no Welsh name
+func (g Geography) NameIn(language string) string {
+	return localName(language, g.Name, g.WelshName)
+}
+
+// countries maps the first letter of a GSS code to the country it belongs to
+var countries = map[byte]Geography{
+	'E': {Level: LevelCountry, Code: "E92000001", Name: "England", WelshName: "Lloegr"},
+	'W': {Level: LevelCountry, Code: "W92000004", Name: "Wales", WelshName: "Cymru"},
+	'S': {Level: LevelCountry, Code: "S92000003", Name: "Scotland", WelshName: "Yr Alban"},
+	'N': {Level: LevelCountry, Code: "N92000002", Name: "Northern Ireland", WelshName: "Gogledd Iwerddon"},
+}
+
+// CountryOfCode returns the country a GSS code belongs to, or an empty
+// Geography if the code does not start with a known country prefix
+func CountryOfCode(code string) Geography {
+	if code == "" {
+		return Geography{}
+	}
+
+	return countries[strings.ToUpper(code[:1])[0]]
+}
+
+// countryOrder is the order countries are listed in, following ONS practice
+const countryOrder = "EWSN"
+
+// Countries returns every country of the UK
+func Countries() []Geography {
+	all := make([]Geography, 0, len(countryOrder))
+
+	for i := range len(countryOrder) {
+		all = append(all, countries[countryOrder[i]])
+	}
+
+	return all
+}
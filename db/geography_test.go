@@ -0,0 +1,37 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountryOfCode(t *testing.T) {
+	tests := []struct {
+		code     string
+		expected string
+	}{
+		{"E00000001", "England"},
+		{"w01000001", "Wales"},
+		{"S00088956", "Scotland"},
+		{"N00000001", "Northern Ireland"},
+		{"X12345678", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			assert.Equal(t, tt.expected, CountryOfCode(tt.code).Name)
+		})
+	}
+}
+
+func TestCountries(t *testing.T) {
+	var names []string
+	for _, country := range Countries() {
+		assert.Equal(t, LevelCountry, country.Level)
+		names = append(names, country.Name)
+	}
+
+	assert.Equal(t, []string{"England", "Wales", "Scotland", "Northern Ireland"}, names)
+}
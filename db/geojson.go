@@ -0,0 +1,175 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/config"
+)
+
+type geoJSONFeature struct {
+	Properties map[string]any `json:"properties"`
+	Geometry   *struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	} `json:"geometry"`
+}
+
+// readGeoJSONBoundaries reads the Polygon and MultiPolygon features of a
+// GeoJSON FeatureCollection one at a time, so the whole file is never held in
+// memory. Features in British National Grid, named by the file's crs or
+// found by the size of their values, are converted to WGS84.
+func readGeoJSONBoundaries(cfg *config.Config, add func(code string, rings [][][2]float64)) error {
+	file, err := os.Open(cfg.BoundaryDataFile)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	dec := json.NewDecoder(file)
+
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	proj := projectionUnknown
+
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		if key == "crs" {
+			if proj, err = decodeGeoJSONCRS(dec); err != nil {
+				return fmt.Errorf("%s: %w", cfg.BoundaryDataFile, err)
+			}
+
+			continue
+		}
+
+		if key != "features" {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err := expectDelim(dec, '['); err != nil {
+			return err
+		}
+
+		for dec.More() {
+			var feature geoJSONFeature
+			if err := dec.Decode(&feature); err != nil {
+				return err
+			}
+
+			if err := addGeoJSONFeature(&feature, cfg.BoundaryCodeProperty, proj, add); err != nil {
+				return err
+			}
+		}
+
+		if err := expectDelim(dec, ']'); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if token != delim {
+		return fmt.Errorf("invalid GeoJSON, expected %q but found %v", delim, token)
+	}
+
+	return nil
+}
+
+// decodeGeoJSONCRS returns the projection named by the crs member of a
+// GeoJSON file, as written by GDAL and older GeoJSON, e.g.
+// {"type": "name", "properties": {"name": "urn:ogc:def:crs:EPSG::27700"}}
+func decodeGeoJSONCRS(dec *json.Decoder) (projection, error) {
+	var crs struct {
+		Properties struct {
+			Name string `json:"name"`
+		} `json:"properties"`
+	}
+
+	if err := dec.Decode(&crs); err != nil {
+		return projectionUnknown, err
+	}
+
+	name := crs.Properties.Name
+
+	switch {
+	case name == "":
+		return projectionUnknown, nil
+	case strings.HasSuffix(name, ":27700"):
+		return projectionBNG, nil
+	case strings.HasSuffix(name, ":4326"), strings.HasSuffix(name, ":CRS84"):
+		return projectionWGS84, nil
+	default:
+		return projectionUnknown, fmt.Errorf("unsupported coordinate reference system %q, expected WGS84 or British National Grid", name)
+	}
+}
+
+func addGeoJSONFeature(feature *geoJSONFeature, codeProperty string, proj projection, add func(code string, rings [][][2]float64)) error {
+	code, ok := feature.Properties[codeProperty].(string)
+	if !ok || feature.Geometry == nil {
+		return nil
+	}
+
+	var rings [][][2]float64
+
+	switch feature.Geometry.Type {
+	case "Polygon":
+		var polygon [][][]float64
+		if err := json.Unmarshal(feature.Geometry.Coordinates, &polygon); err != nil {
+			return err
+		}
+
+		rings = appendGeoJSONRings(rings, polygon)
+	case "MultiPolygon":
+		var polygons [][][][]float64
+		if err := json.Unmarshal(feature.Geometry.Coordinates, &polygons); err != nil {
+			return err
+		}
+
+		for _, polygon := range polygons {
+			rings = appendGeoJSONRings(rings, polygon)
+		}
+	default:
+		return nil
+	}
+
+	add(code, proj.lonLat(rings))
+
+	return nil
+}
+
+func appendGeoJSONRings(rings [][][2]float64, polygon [][][]float64) [][][2]float64 {
+	for _, positions := range polygon {
+		ring := make([][2]float64, 0, len(positions))
+
+		for _, position := range positions {
+			if len(position) >= 2 {
+				ring = append(ring, [2]float64{position[0], position[1]})
+			}
+		}
+
+		rings = append(rings, ring)
+	}
+
+	return rings
+}
@@ -0,0 +1,172 @@
+package db
+
+import (
+	"slices"
+	"strings"
+)
+
+// Index is an immutable radix tree mapping string keys to values of type T.
+//
+// Values are held in a single slice sorted by key, so the values stored at a
+// key, and those stored anywhere below it, are each a contiguous run of that
+// slice and lookups never allocate. Edge labels are substrings of the keys, so
+// the tree holds no copies of key data.
+type Index[T any] struct {
+	nodes  []indexNode
+	values []T
+}
+
+// indexNode is a node of an Index. Children of a node are stored next to each
+// other in Index.nodes, sorted by the first byte of their label.
+type indexNode struct {
+	label     string
+	children  int32
+	nChildren int32
+	// values[lo:mid] are stored at this node's key and values[lo:hi] at this
+	// node's key or any key below it
+	lo, mid, hi int32
+}
+
+// NewIndex builds an Index holding values under the key returned by key.
+// Values that share a key are returned in the order they were given.
+func NewIndex[T any](values []T, key func(T) string) *Index[T] {
+	keys := make([]string, len(values))
+	order := make([]int, len(values))
+
+	for i := range values {
+		keys[i] = key(values[i])
+		order[i] = i
+	}
+
+	slices.SortStableFunc(order, func(a, b int) int {
+		return strings.Compare(keys[a], keys[b])
+	})
+
+	idx := &Index[T]{
+		nodes:  make([]indexNode, 1, len(values)+1),
+		values: make([]T, len(values)),
+	}
+
+	sortedKeys := make([]string, len(values))
+	for i, o := range order {
+		idx.values[i] = values[o]
+		sortedKeys[i] = keys[o]
+	}
+
+	idx.build(0, sortedKeys, 0, len(sortedKeys), 0)
+
+	return idx
+}
+
+// build fills in node n, which covers sortedKeys[lo:hi], all of which share
+// their first depth bytes
+func (idx *Index[T]) build(n int, sortedKeys []string, lo, hi, depth int) {
+	mid := lo
+	for mid < hi && len(sortedKeys[mid]) == depth {
+		mid++
+	}
+
+	idx.nodes[n].lo, idx.nodes[n].mid, idx.nodes[n].hi = int32(lo), int32(mid), int32(hi) // #nosec G115 -- bounded by the number of values
+
+	type group struct{ lo, hi, depth int }
+
+	var groups []group
+
+	for start := mid; start < hi; {
+		end := start + 1
+		for end < hi && sortedKeys[end][depth] == sortedKeys[start][depth] {
+			end++
+		}
+
+		// keys are sorted so the prefix shared by the first and last key of a
+		// group is shared by the whole group
+		groups = append(groups, group{start, end, depth + commonPrefixLen(sortedKeys[start][depth:], sortedKeys[end-1][depth:])})
+		start = end
+	}
+
+	if len(groups) == 0 {
+		return
+	}
+
+	first := len(idx.nodes)
+	idx.nodes[n].children, idx.nodes[n].nChildren = int32(first), int32(len(groups)) // #nosec G115 -- bounded by the number of values
+
+	for _, g := range groups {
+		idx.nodes = append(idx.nodes, indexNode{label: sortedKeys[g.lo][depth:g.depth]})
+	}
+
+	for i, g := range groups {
+		idx.build(first+i, sortedKeys, g.lo, g.hi, g.depth)
+	}
+}
+
+func commonPrefixLen(a, b string) int {
+	n := min(len(a), len(b))
+
+	for i := 0; i < n; i++ {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+
+	return n
+}
+
+// find returns the node at which key ends, and whether key ends exactly at
+// that node rather than part of the way along the edge leading to it
+func (idx *Index[T]) find(key string) (*indexNode, bool) {
+	node := &idx.nodes[0]
+
+	for key != "" {
+		children := idx.nodes[node.children : node.children+node.nChildren]
+
+		i, found := slices.BinarySearchFunc(children, key[0], func(child indexNode, b byte) int {
+			return int(child.label[0]) - int(b)
+		})
+		if !found {
+			return nil, false
+		}
+
+		child := &children[i]
+
+		switch {
+		case strings.HasPrefix(key, child.label):
+			key = key[len(child.label):]
+			node = child
+		case strings.HasPrefix(child.label, key):
+			return child, false
+		default:
+			return nil, false
+		}
+	}
+
+	return node, true
+}
+
+// Get returns the values stored at exactly key. The returned slice is shared
+// by every caller and must not be modified.
+func (idx *Index[T]) Get(key string) []T {
+	node, exact := idx.find(key)
+	if node == nil || !exact {
+		return nil
+	}
+
+	return idx.values[node.lo:node.mid:node.mid]
+}
+
+// GetByPrefix returns the values stored at every key starting with prefix, in
+// key order. The returned slice is shared by every caller and must not be
+// modified.
+func (idx *Index[T]) GetByPrefix(prefix string) []T {
+	node, _ := idx.find(prefix)
+	if node == nil {
+		return nil
+	}
+
+	return idx.values[node.lo:node.hi:node.hi]
+}
+
+// Len returns the number of values held in the index
+func (idx *Index[T]) Len() int {
+	return len(idx.values)
+}
@@ -0,0 +1,251 @@
+package db
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/config"
+	"github.com/alediaferia/prefixmap"
+	"github.com/stretchr/testify/assert"
+)
+
+type indexTestValue struct {
+	key   string
+	value int
+}
+
+func indexTestKey(v indexTestValue) string {
+	return v.key
+}
+
+func TestIndex(t *testing.T) {
+	idx := NewIndex([]indexTestValue{
+		{"E01", 1},
+		{"E0", 2},
+		{"E02", 3},
+		{"W01", 4},
+		{"E01", 5},
+		{"E0123", 6},
+	}, indexTestKey)
+
+	tests := []struct {
+		name     string
+		get      func(string) []indexTestValue
+		key      string
+		expected []int
+	}{
+		{"exact key", idx.Get, "E02", []int{3}},
+		{"exact key shared by values keeps insertion order", idx.Get, "E01", []int{1, 5}},
+		{"exact key that is a prefix of other keys", idx.Get, "E0", []int{2}},
+		{"exact key ending part way along an edge", idx.Get, "E012", nil},
+		{"exact key that is missing", idx.Get, "E03", nil},
+		{"exact key longer than any key", idx.Get, "E01234", nil},
+		{"prefix matching several keys", idx.GetByPrefix, "E0", []int{2, 1, 5, 6, 3}},
+		{"prefix ending part way along an edge", idx.GetByPrefix, "E012", []int{6}},
+		{"prefix matching a single key", idx.GetByPrefix, "W", []int{4}},
+		{"prefix that is missing", idx.GetByPrefix, "S", nil},
+		{"empty prefix", idx.GetByPrefix, "", []int{2, 1, 5, 6, 3, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []int
+			for _, v := range tt.get(tt.key) {
+				got = append(got, v.value)
+			}
+
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+
+	assert.Equal(t, 6, idx.Len())
+}
+
+func TestEmptyIndex(t *testing.T) {
+	idx := NewIndex(nil, indexTestKey)
+
+	assert.Equal(t, 0, idx.Len())
+	assert.Empty(t, idx.Get("E01"))
+	assert.Empty(t, idx.GetByPrefix(""))
+}
+
+func TestInternAreas(t *testing.T) {
+	areas := []Area{
+		{OutputAreaCode: "OAC1", LAName: strings.Clone("City of London")},
+		{OutputAreaCode: "OAC2", LAName: strings.Clone("City of London")},
+	}
+
+	internAreas(areas)
+
+	assert.Same(t, unsafe.StringData(areas[0].LAName), unsafe.StringData(areas[1].LAName))
+}
+
+// benchmarkAreaCount is the size of the full output area file
+const benchmarkAreaCount = 230000
+
+// benchmarkAreas returns the production output area data if it is available
+// locally, or generated data of the same size and shape otherwise
+func benchmarkAreas(b *testing.B) []Area {
+	cfg, err := config.Get()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	cfg.AreaDataFile = "../" + cfg.AreaDataFile
+
+	if areas, err := getArea(cfg); err == nil {
+		return areas
+	}
+
+	areas := make([]Area, benchmarkAreaCount)
+	for i := range areas {
+		la, region := i/700, i/25000
+
+		// fresh copies of each string, as the CSV parser would give us
+		areas[i] = Area{
+			OutputAreaCode:     fmt.Sprintf("E%08d", i+1),
+			LocalAuthorityCode: fmt.Sprintf("E%08d", 6000000+la),
+			LAName:             fmt.Sprintf("Local Authority %d", la),
+			RegionCode:         fmt.Sprintf("E%08d", 12000000+region),
+			RegionName:         fmt.Sprintf("Region %d", region),
+		}
+	}
+
+	return areas
+}
+
+func benchmarkIndustries(b *testing.B) []Industry {
+	cfg, err := config.Get()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	cfg.IndustryDataFile = "../" + cfg.IndustryDataFile
+
+	industries, err := getIndustry(cfg)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	return industries
+}
+
+func buildAreaPrefixMap(areas []Area) *prefixmap.PrefixMap {
+	m := prefixmap.New()
+	for _, area := range areas {
+		m.Insert(area.OutputAreaCode, area)
+	}
+
+	return m
+}
+
+func buildAreaIndex(areas []Area) *Index[*Area] {
+	internAreas(areas)
+
+	return indexAreas(areas, outputAreaKey)
+}
+
+// heapUsed returns the bytes of heap still in use by the result of build
+func heapUsed(build func() any) uint64 {
+	var before, after runtime.MemStats
+
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	result := build()
+
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	runtime.KeepAlive(result)
+
+	return after.HeapAlloc - before.HeapAlloc
+}
+
+func BenchmarkBuildAreas(b *testing.B) {
+	b.Run("prefixmap", func(b *testing.B) {
+		b.ReportMetric(float64(heapUsed(func() any { return buildAreaPrefixMap(benchmarkAreas(b)) })), "heap-B")
+
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			areas := benchmarkAreas(b)
+			b.StartTimer()
+
+			buildAreaPrefixMap(areas)
+		}
+	})
+
+	b.Run("index", func(b *testing.B) {
+		b.ReportMetric(float64(heapUsed(func() any { return buildAreaIndex(benchmarkAreas(b)) })), "heap-B")
+
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			areas := benchmarkAreas(b)
+			b.StartTimer()
+
+			buildAreaIndex(areas)
+		}
+	})
+}
+
+func BenchmarkGetArea(b *testing.B) {
+	areas := benchmarkAreas(b)
+
+	codes := make([]string, 0, len(areas))
+	for _, area := range areas {
+		codes = append(codes, area.OutputAreaCode)
+	}
+
+	b.Run("prefixmap", func(b *testing.B) {
+		m := buildAreaPrefixMap(areas)
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			for _, v := range m.Get(codes[i%len(codes)]) {
+				_ = v.(Area)
+			}
+		}
+	})
+
+	b.Run("index", func(b *testing.B) {
+		idx := buildAreaIndex(areas)
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			for _, area := range idx.Get(codes[i%len(codes)]) {
+				_ = area
+			}
+		}
+	})
+}
+
+func BenchmarkGetIndustry(b *testing.B) {
+	industries := benchmarkIndustries(b)
+
+	b.Run("prefixmap", func(b *testing.B) {
+		m := prefixmap.New()
+		for _, industry := range industries {
+			m.Insert(industry.Code, industry)
+		}
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			for _, v := range m.Get(industries[i%len(industries)].Code) {
+				_ = v.(Industry)
+			}
+		}
+	})
+
+	b.Run("index", func(b *testing.B) {
+		idx := NewIndex(industries, industryKey)
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			for _, industry := range idx.Get(industries[i%len(industries)].Code) {
+				_ = industry
+			}
+		}
+	})
+}
@@ -8,8 +8,15 @@ import (
 )
 
 type Industry struct {
-	Code string `csv:"SIC Code"`
-	Name string `csv:"Description"`
+	Code      string `csv:"SIC Code"`
+	Name      string `csv:"Description"`
+	WelshName string `csv:"-"`
+}
+
+// NameIn returns the name of the industry in language, falling back to
+// English when there is no Welsh name
+func (i Industry) NameIn(language string) string {
+	return localName(language, i.Name, i.WelshName)
 }
 
 func getIndustry(cfg *config.Config) ([]Industry, error) {
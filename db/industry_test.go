@@ -38,6 +38,28 @@ func TestGetIndustry(t *testing.T) {
 	}
 }
 
+func TestIndustrySynonymsOfTrades(t *testing.T) {
+	synonyms, err := getIndustrySynonyms(&config.Config{IndustrySynonymFile: "../data/SIC07_synonyms_en.csv"})
+	if err != nil {
+		t.Fatalf("Failed to read the trades: %v", err)
+	}
+
+	si := NewSynonymIndex(synonyms, testStopWords)
+
+	// the trade is found by the bread it makes and sells, not the baked clay
+	// of 23320
+	for _, q := range []string{"bakers", "bakery", "baking"} {
+		var codes []string
+		for _, match := range si.Find(testStopWords.Terms(q)) {
+			for _, synonym := range match.Values {
+				codes = append(codes, synonym.Code)
+			}
+		}
+
+		assert.ElementsMatch(t, []string{"10710", "47240"}, codes, q)
+	}
+}
+
 func mockIndustryData(t *testing.T) []Industry {
 	testFile, err := os.Create("test.csv")
 	if err != nil {
@@ -0,0 +1,36 @@
+package db
+
+// interner hands back one shared copy of each distinct string it is given, so
+// that values repeated across thousands of rows, such as local authority and
+// region names, are only held in memory once
+type interner map[string]string
+
+func (in interner) intern(s string) string {
+	if shared, ok := in[s]; ok {
+		return shared
+	}
+
+	in[s] = s
+
+	return s
+}
+
+// internAreas replaces the repeated fields of each area with shared copies
+func internAreas(areas []Area) {
+	in := interner{}
+
+	for i := range areas {
+		areas[i].LocalAuthorityCode = in.intern(areas[i].LocalAuthorityCode)
+		areas[i].LAName = in.intern(areas[i].LAName)
+		areas[i].RegionCode = in.intern(areas[i].RegionCode)
+		areas[i].RegionName = in.intern(areas[i].RegionName)
+		areas[i].LAWelshName = in.intern(areas[i].LAWelshName)
+		areas[i].RegionWelshName = in.intern(areas[i].RegionWelshName)
+		areas[i].SupergroupCode = in.intern(areas[i].SupergroupCode)
+		areas[i].SupergroupName = in.intern(areas[i].SupergroupName)
+		areas[i].LSOACode = in.intern(areas[i].LSOACode)
+		areas[i].LSOAName = in.intern(areas[i].LSOAName)
+		areas[i].MSOACode = in.intern(areas[i].MSOACode)
+		areas[i].MSOAName = in.intern(areas[i].MSOAName)
+	}
+}
@@ -0,0 +1,384 @@
+package db
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/text"
+)
+
+// PhraseIndex finds values by name in a list of terms, matching names of
+// more than one word as well as single words. Names and terms are both
+// normalised by text.StopWords.Terms, with the same stop words.
+type PhraseIndex[T any] struct {
+	index    *Index[T]
+	maxTerms int
+}
+
+// NewPhraseIndex indexes values under the terms of the name returned by name,
+// leaving out stopWords and the values whose name has no other terms
+func NewPhraseIndex[T any](values []T, name func(T) string, stopWords text.StopWords) *PhraseIndex[T] {
+	pi := &PhraseIndex[T]{}
+
+	keyed := make([]T, 0, len(values))
+
+	for _, value := range values {
+		terms := stopWords.Terms(name(value))
+		if len(terms) == 0 {
+			continue
+		}
+
+		pi.maxTerms = max(pi.maxTerms, len(terms))
+		keyed = append(keyed, value)
+	}
+
+	pi.index = NewIndex(keyed, func(value T) string {
+		return strings.Join(stopWords.Terms(name(value)), " ")
+	})
+
+	return pi
+}
+
+// Len returns the number of values held in the index
+func (pi *PhraseIndex[T]) Len() int {
+	if pi == nil {
+		return 0
+	}
+
+	return pi.index.Len()
+}
+
+// PhraseMatch is a name found in a list of terms, running from Start up to
+// but not including End, and the values it names
+type PhraseMatch[T any] struct {
+	Values     []T
+	Start, End int
+}
+
+// Find returns the names found in terms, in the order they appear. Where
+// names overlap the longest is taken, so "dental surgeon" is found rather
+// than "surgeon".
+func (pi *PhraseIndex[T]) Find(terms []string) []PhraseMatch[T] {
+	if pi.Len() == 0 {
+		return nil
+	}
+
+	var found []PhraseMatch[T]
+
+	for i := 0; i < len(terms); {
+		n := min(pi.maxTerms, len(terms)-i)
+
+		for ; n > 0; n-- {
+			if values := pi.index.Get(strings.Join(terms[i:i+n], " ")); len(values) > 0 {
+				found = append(found, PhraseMatch[T]{Values: values, Start: i, End: i + n})
+				break
+			}
+		}
+
+		i += max(n, 1)
+	}
+
+	return found
+}
+
+// FindWhole returns the values named by the whole of terms, or nothing if
+// terms are not exactly one name
+func (pi *PhraseIndex[T]) FindWhole(terms []string) []T {
+	if pi.Len() == 0 || len(terms) == 0 {
+		return nil
+	}
+
+	return pi.index.Get(strings.Join(terms, " "))
+}
+
+// Unmatched returns the terms that are not part of any of matches
+func Unmatched[T any](terms []string, matches []PhraseMatch[T]) []string {
+	var unmatched []string
+
+	next := 0
+
+	for _, m := range matches {
+		unmatched = append(unmatched, terms[next:m.Start]...)
+		next = m.End
+	}
+
+	return append(unmatched, terms[next:]...)
+}
+
+// maxWordMatches is the most values a search of a WordIndex returns, beyond
+// which the terms are too vague to say which values were meant
+const maxWordMatches = 10
+
+// WordIndex finds values by the individual terms of their names, for names
+// such as SIC descriptions that are too long to be given in full
+type WordIndex[T any] struct {
+	index *Index[wordEntry[T]]
+}
+
+type wordEntry[T any] struct {
+	term  string
+	value T
+	// id tells values apart when counting the terms each one matched
+	id int
+	// terms is the number of distinct terms in the name of the value
+	terms int
+}
+
+// NewWordIndex indexes values under each of the terms of the name returned by
+// name, leaving out stopWords
+func NewWordIndex[T any](values []T, name func(T) string, stopWords text.StopWords) *WordIndex[T] {
+	var entries []wordEntry[T]
+
+	for i, value := range values {
+		var terms []string
+
+		for _, term := range stopWords.Terms(name(value)) {
+			if !slices.Contains(terms, term) {
+				terms = append(terms, term)
+			}
+		}
+
+		for _, term := range terms {
+			entries = append(entries, wordEntry[T]{term: term, value: value, id: i, terms: len(terms)})
+		}
+	}
+
+	return &WordIndex[T]{
+		index: NewIndex(entries, func(e wordEntry[T]) string { return e.term }),
+	}
+}
+
+// Find returns the values whose names share the most terms with terms, in the
+// order they were indexed. Of those, values whose names are much longer than
+// the closest match are left out, so "growing rice" finds "Growing of rice"
+// but not "Growing of cereals (except rice)". Nothing is returned unless
+// most of terms are in the names found, as "house prices" is not "Public
+// houses and bars", or if more than maxWordMatches values are equally good
+// matches. A single word that is also in names left out must be at least
+// half of the name found, so "beer" finds "Manufacture of beer" but "health"
+// does not find "Other human health activities".
+func (wi *WordIndex[T]) Find(terms []string) []T {
+	return wi.find(terms, false)
+}
+
+// FindAll returns the values whose names have every one of terms, as for a
+// phrase, chosen in the same way as Find
+func (wi *WordIndex[T]) FindAll(terms []string) []T {
+	return wi.find(terms, true)
+}
+
+func (wi *WordIndex[T]) find(terms []string, all bool) []T {
+	if wi == nil {
+		return nil
+	}
+
+	scores := make(map[int]int)
+	entries := make(map[int]wordEntry[T])
+	best := 0
+
+	seen := make(map[string]bool)
+
+	for _, term := range terms {
+		if seen[term] {
+			continue
+		}
+
+		seen[term] = true
+
+		for _, e := range wi.index.Get(term) {
+			scores[e.id]++
+			entries[e.id] = e
+			best = max(best, scores[e.id])
+		}
+	}
+
+	if all && best < len(seen) {
+		return nil
+	}
+
+	// most of the terms must be in the name, not just one of several
+	if 2*best <= len(seen) && best < len(seen) {
+		return nil
+	}
+
+	// the share of its name matched by the closest match
+	bestCoverage := 0.0
+
+	for id, score := range scores {
+		if score == best {
+			bestCoverage = max(bestCoverage, float64(score)/float64(entries[id].terms))
+		}
+	}
+
+	var ids []int
+
+	left := 0
+
+	for id, score := range scores {
+		if score != best {
+			continue
+		}
+
+		if 2*float64(score)/float64(entries[id].terms) >= bestCoverage {
+			ids = append(ids, id)
+		} else {
+			left++
+		}
+	}
+
+	// a single word that is also in names left out is too vague unless it is
+	// at least half of the name found
+	if best == 1 && left > 0 && bestCoverage < 0.5 {
+		return nil
+	}
+
+	if len(ids) == 0 || len(ids) > maxWordMatches {
+		return nil
+	}
+
+	slices.Sort(ids)
+
+	found := make([]T, 0, len(ids))
+	for _, id := range ids {
+		found = append(found, entries[id].value)
+	}
+
+	return found
+}
+
+// AreaName is the name of a local authority or region, in English or Welsh,
+// and an output area within it from which the rest of its hierarchy is found
+type AreaName struct {
+	Level string
+	Code  string
+	Name  string
+	Area  *Area
+	// Place is the town or city named, and Areas an area in each of its
+	// local authorities, for names at LevelPlace
+	Place *Place
+	Areas []*Area
+}
+
+// Hierarchy returns the chain of geographies from the named area up to its
+// country. The chain of a place that crosses local authorities has each of
+// them, followed by each of their regions and countries.
+func (n AreaName) Hierarchy() []Geography {
+	if n.Level == LevelPlace {
+		return n.placeHierarchy()
+	}
+
+	chain := n.Area.Hierarchy()
+
+	for i, g := range chain {
+		if g.Level == n.Level {
+			return chain[i:]
+		}
+	}
+
+	return nil
+}
+
+func (n AreaName) placeHierarchy() []Geography {
+	areas := n.Areas
+	if len(areas) == 0 {
+		areas = []*Area{n.Area}
+	}
+
+	chain := []Geography{{Level: LevelPlace, Code: n.Code, Name: n.Name}}
+
+	for _, level := range []string{LevelLocalAuthority, LevelRegion, LevelCountry} {
+		for _, area := range areas {
+			for _, g := range area.Hierarchy() {
+				if g.Level == level && !slices.ContainsFunc(chain, func(c Geography) bool { return c.Code == g.Code }) {
+					chain = append(chain, g)
+				}
+			}
+		}
+	}
+
+	return chain
+}
+
+// NameIn returns the name of the named area in language, whichever language
+// it was found by
+func (n AreaName) NameIn(language string) string {
+	if n.Level == LevelPlace {
+		return n.Name
+	}
+
+	if n.Level == LevelRegion {
+		return n.Area.RegionNameIn(language)
+	}
+
+	return n.Area.LANameIn(language)
+}
+
+// areaNames returns the name of every local authority and region of areas,
+// in English and Welsh. Welsh names are also given in each of their mutated
+// forms, so "yng Nghaerdydd" finds Caerdydd.
+func areaNames(areas []Area) []AreaName {
+	var names []AreaName
+
+	seen := make(map[string]bool)
+
+	add := func(level, code, name string, area *Area) {
+		if code != "" && name != "" && !seen[level+code+name] {
+			seen[level+code+name] = true
+			names = append(names, AreaName{Level: level, Code: code, Name: name, Area: area})
+		}
+	}
+
+	addWelsh := func(level, code, name string, area *Area) {
+		add(level, code, name, area)
+
+		first, rest, _ := strings.Cut(name, " ")
+		for _, form := range text.Mutations(first) {
+			add(level, code, strings.TrimSpace(form+" "+rest), area)
+		}
+	}
+
+	for i := range areas {
+		area := &areas[i]
+
+		add(LevelLocalAuthority, area.LocalAuthorityCode, area.LAName, area)
+		addWelsh(LevelLocalAuthority, area.LocalAuthorityCode, area.LAWelshName, area)
+
+		// the region of a welsh area is Wales itself, which is found as a
+		// country instead
+		if area.RegionCode != area.Country().Code {
+			add(LevelRegion, area.RegionCode, area.RegionName, area)
+			addWelsh(LevelRegion, area.RegionCode, area.RegionWelshName, area)
+		}
+	}
+
+	return names
+}
+
+// IndustryName is the name of an industry in English or Welsh
+type IndustryName struct {
+	Name     string
+	Industry Industry
+}
+
+// industryNames returns the English and Welsh names of industries
+func industryNames(industries []Industry) []IndustryName {
+	names := make([]IndustryName, 0, len(industries))
+
+	for _, industry := range industries {
+		names = append(names, IndustryName{Name: industry.Name, Industry: industry})
+
+		if industry.WelshName != "" && industry.WelshName != industry.Name {
+			names = append(names, IndustryName{Name: industry.WelshName, Industry: industry})
+		}
+	}
+
+	return names
+}
+
+func areaNameKey(name AreaName) string {
+	return name.Name
+}
+
+func industryNameKey(name IndustryName) string {
+	return name.Name
+}
@@ -0,0 +1,233 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/config"
+	"github.com/ONSdigital/dp-search-scrubber-api/text"
+	"github.com/stretchr/testify/assert"
+)
+
+// testStopWords are the stop words that names and terms are normalised
+// without
+var testStopWords = text.DefaultStopWords()
+
+func TestPhraseIndexFind(t *testing.T) {
+	pi := NewPhraseIndex([]string{"Dentist", "Dental surgeon", "Surgeon", "Orthodontist (NHS)", "of the", "Ynys Môn"}, func(s string) string { return s }, testStopWords)
+
+	// "of the" is only stop words
+	assert.Equal(t, 5, pi.Len())
+
+	tests := []struct {
+		name      string
+		q         string
+		expected  []string
+		unmatched []string
+	}{
+		{name: "single word", q: "Dentists", expected: []string{"Dentist"}},
+		{name: "longest name first", q: "dental surgeons near Hull", expected: []string{"Dental surgeon"}, unmatched: []string{"hull"}},
+		{name: "name on its own", q: "surgeon dental", expected: []string{"Surgeon"}, unmatched: []string{"dental"}},
+		{name: "punctuation ignored", q: "orthodontists, NHS", expected: []string{"Orthodontist (NHS)"}},
+		{name: "no names", q: "bakers", unmatched: []string{"bake"}},
+		{name: "accents", q: "cafés in Ynys Môn", expected: []string{"Ynys Môn"}, unmatched: []string{"cafe"}},
+		{name: "accents left out", q: "ynys mon", expected: []string{"Ynys Môn"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			terms := testStopWords.Terms(tt.q)
+			matches := pi.Find(terms)
+
+			var found []string
+			for _, m := range matches {
+				found = append(found, m.Values...)
+			}
+
+			assert.Equal(t, tt.expected, found)
+			assert.Equal(t, tt.unmatched, Unmatched(terms, matches))
+		})
+	}
+
+	var empty *PhraseIndex[string]
+	assert.Equal(t, 0, empty.Len())
+	assert.Nil(t, empty.Find([]string{"dentist"}))
+}
+
+func TestWordIndexFind(t *testing.T) {
+	industries := []Industry{
+		{Code: "01110", Name: "Growing of cereals (except rice), leguminous crops and oil seeds"},
+		{Code: "01120", Name: "Growing of rice"},
+		{Code: "01130", Name: "Growing of vegetables and melons, roots and tubers"},
+		{Code: "10710", Name: "Manufacture of bread; manufacture of fresh pastry goods and cakes"},
+		{Code: "47240", Name: "Retail sale of bread, cakes, flour confectionery and sugar confectionery in specialised stores"},
+		{Code: "86230", Name: "Dental practice activities", WelshName: "Gweithgareddau practisau deintyddol"},
+	}
+
+	wi := NewWordIndex(industryNames(industries), industryNameKey, testStopWords)
+
+	tests := []struct {
+		q        string
+		expected []string
+	}{
+		{q: "growing rice", expected: []string{"01120"}},
+		{q: "fresh bread", expected: []string{"10710"}},
+		{q: "bread", expected: []string{"10710", "47240"}},
+		{q: "melon growers", expected: []string{"01130"}},
+		{q: "dentists", expected: nil},
+		{q: "practisau deintyddol", expected: []string{"86230"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.q, func(t *testing.T) {
+			var codes []string
+			for _, name := range wi.Find(testStopWords.Terms(tt.q)) {
+				codes = append(codes, name.Industry.Code)
+			}
+
+			assert.Equal(t, tt.expected, codes)
+		})
+	}
+
+	var empty *WordIndex[IndustryName]
+	assert.Nil(t, empty.Find([]string{"bread"}))
+}
+
+func TestWordIndexFindAll(t *testing.T) {
+	industries := []Industry{
+		{Code: "10710", Name: "Manufacture of bread; manufacture of fresh pastry goods and cakes"},
+		{Code: "47240", Name: "Retail sale of bread, cakes, flour confectionery and sugar confectionery in specialised stores"},
+	}
+
+	wi := NewWordIndex(industryNames(industries), industryNameKey, testStopWords)
+
+	var codes []string
+	for _, name := range wi.FindAll(testStopWords.Terms("retail of bread")) {
+		codes = append(codes, name.Industry.Code)
+	}
+
+	assert.Equal(t, []string{"47240"}, codes)
+
+	// every word must be in the name, where most of them are enough to find it
+	assert.Nil(t, wi.FindAll(testStopWords.Terms("retail of bread and pies")))
+	assert.Len(t, wi.Find(testStopWords.Terms("retail of bread and pies")), 1)
+}
+
+func TestPhraseIndexFindWhole(t *testing.T) {
+	pi := NewPhraseIndex([]string{"Dental surgeon", "Surgeon"}, func(s string) string { return s }, testStopWords)
+
+	assert.Equal(t, []string{"Dental surgeon"}, pi.FindWhole(testStopWords.Terms("dental surgeons")))
+	assert.Nil(t, pi.FindWhole(testStopWords.Terms("dental surgeons in london")))
+	assert.Nil(t, pi.FindWhole(nil))
+}
+
+func TestWordIndexFindTooVague(t *testing.T) {
+	var industries []Industry
+	for i := 0; i <= maxWordMatches; i++ {
+		industries = append(industries, Industry{Code: string(rune('a' + i)), Name: "Growing of crops"})
+	}
+
+	assert.Nil(t, NewWordIndex(industryNames(industries), industryNameKey, testStopWords).Find(testStopWords.Terms("growing")))
+}
+
+func TestWordIndexFindSIC2007(t *testing.T) {
+	industries, err := getIndustry(&config.Config{IndustryDataFile: "../data/SIC07_CH_condensed_list_en.csv"})
+	if err != nil {
+		t.Fatalf("Failed to read SIC 2007: %v", err)
+	}
+
+	wi := NewWordIndex(industryNames(industries), industryNameKey, testStopWords)
+
+	tests := []struct {
+		q        string
+		expected []string
+	}{
+		{q: "growing rice", expected: []string{"01120"}},
+		{q: "bread", expected: []string{"10710", "47240"}},
+		{q: "beer", expected: []string{"11050"}},
+		{q: "human health", expected: []string{"86900"}},
+		// one word of several is not enough
+		{q: "house prices", expected: nil},
+		{q: "travel to work", expected: nil},
+		{q: "school leavers", expected: nil},
+		// a word that is only a small part of many names
+		{q: "health", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.q, func(t *testing.T) {
+			var codes []string
+			for _, name := range wi.Find(testStopWords.Terms(tt.q)) {
+				codes = append(codes, name.Industry.Code)
+			}
+
+			assert.Equal(t, tt.expected, codes)
+		})
+	}
+}
+
+func TestAreaNames(t *testing.T) {
+	areas := []Area{
+		{OutputAreaCode: "E00000001", LocalAuthorityCode: "E09000001", LAName: "City of London", RegionCode: "E12000007", RegionName: "London"},
+		{OutputAreaCode: "E00000003", LocalAuthorityCode: "E09000001", LAName: "City of London", RegionCode: "E12000007", RegionName: "London"},
+		{OutputAreaCode: "W00000001", LocalAuthorityCode: "W06000001", LAName: "Isle of Anglesey", RegionCode: "W92000004", RegionName: "Wales"},
+	}
+
+	names := areaNames(areas)
+
+	var found []string
+	for _, name := range names {
+		found = append(found, name.Level+" "+name.Name)
+	}
+
+	// Wales is a country rather than a region
+	assert.Equal(t, []string{"local_authority City of London", "region London", "local_authority Isle of Anglesey"}, found)
+
+	assert.Equal(t, []Geography{
+		{Level: LevelRegion, Code: "E12000007", Name: "London"},
+		{Level: LevelCountry, Code: "E92000001", Name: "England", WelshName: "Lloegr"},
+	}, names[1].Hierarchy())
+
+	pi := NewPhraseIndex(names, areaNameKey, testStopWords)
+
+	matches := pi.Find(testStopWords.Terms("dentists in the city of london and london"))
+	if assert.Len(t, matches, 2) {
+		assert.Equal(t, "E09000001", matches[0].Values[0].Code)
+		assert.Equal(t, "E12000007", matches[1].Values[0].Code)
+	}
+}
+
+func TestAreaNamesWelsh(t *testing.T) {
+	areas := []Area{
+		{OutputAreaCode: "W00010000", LocalAuthorityCode: "W06000015", LAName: "Cardiff", LAWelshName: "Caerdydd", RegionCode: "W92000004", RegionName: "Wales", RegionWelshName: "Cymru"},
+		{OutputAreaCode: "W00009000", LocalAuthorityCode: "W06000014", LAName: "Vale of Glamorgan", LAWelshName: "Bro Morgannwg", RegionCode: "W92000004", RegionName: "Wales"},
+		{OutputAreaCode: "W00000001", LocalAuthorityCode: "W06000001", LAName: "Isle of Anglesey", LAWelshName: "Ynys Môn", RegionCode: "W92000004", RegionName: "Wales"},
+	}
+
+	pi := NewPhraseIndex(areaNames(areas), areaNameKey, testStopWords)
+
+	tests := []struct {
+		q        string
+		expected string
+	}{
+		{q: "deintyddion yng Nghaerdydd", expected: "W06000015"},
+		{q: "o Gaerdydd", expected: "W06000015"},
+		{q: "Cardiff", expected: "W06000015"},
+		{q: "ym Mro Morgannwg", expected: "W06000014"},
+		{q: "Ynys Mon", expected: "W06000001"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.q, func(t *testing.T) {
+			matches := pi.Find(testStopWords.Terms(tt.q))
+
+			if assert.Len(t, matches, 1) {
+				name := matches[0].Values[0]
+				assert.Equal(t, tt.expected, name.Code)
+			}
+		})
+	}
+
+	name := pi.Find(testStopWords.Terms("Nghaerdydd"))[0].Values[0]
+	assert.Equal(t, "Caerdydd", name.NameIn(text.Welsh))
+	assert.Equal(t, "Cardiff", name.NameIn(text.English))
+}
@@ -0,0 +1,117 @@
+package db
+
+import (
+	"os"
+	"strings"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/config"
+	"github.com/ONSdigital/dp-search-scrubber-api/text"
+	"github.com/gocarina/gocsv"
+)
+
+// The levels of the Standard Occupational Classification 2020, which are
+// told apart by the number of digits of their codes
+const (
+	LevelMajorGroup    = "major_group"
+	LevelSubMajorGroup = "sub_major_group"
+	LevelMinorGroup    = "minor_group"
+	LevelUnitGroup     = "unit_group"
+)
+
+// occupationLevels are the levels of SOC 2020 by the length of their codes
+var occupationLevels = map[int]string{
+	1: LevelMajorGroup,
+	2: LevelSubMajorGroup,
+	3: LevelMinorGroup,
+	4: LevelUnitGroup,
+}
+
+// Occupation is a group of the Standard Occupational Classification 2020 at
+// any level, from a major group such as 2 (professional occupations) to a
+// unit group such as 2253 (dental practitioners)
+type Occupation struct {
+	Code  string
+	Title string
+}
+
+// Level returns the level of the group going by the length of its code
+func (o Occupation) Level() string {
+	return occupationLevels[len(o.Code)]
+}
+
+// OccupationRow is a row of the ONS SOC 2020 structure, which has the code of
+// one group in the column of its level
+type OccupationRow struct {
+	MajorGroup    string `csv:"Major Group"`
+	SubMajorGroup string `csv:"Sub-Major Group"`
+	MinorGroup    string `csv:"Minor Group"`
+	UnitGroup     string `csv:"Unit Group"`
+	Title         string `csv:"Group Title"`
+}
+
+// NewOccupationIndex indexes occupations by their code
+func NewOccupationIndex(occupations []Occupation) *Index[Occupation] {
+	return NewIndex(occupations, occupationKey)
+}
+
+// NewOccupationTitleIndex indexes the unit groups of occupations by their
+// titles, leaving out stopWords
+func NewOccupationTitleIndex(occupations []Occupation, stopWords text.StopWords) *PhraseIndex[Occupation] {
+	units := make([]Occupation, 0, len(occupations))
+
+	for _, occupation := range occupations {
+		if occupation.Level() == LevelUnitGroup {
+			units = append(units, occupation)
+		}
+	}
+
+	return NewPhraseIndex(units, occupationTitleKey, stopWords)
+}
+
+func occupationKey(occupation Occupation) string {
+	return occupation.Code
+}
+
+func occupationTitleKey(occupation Occupation) string {
+	return occupation.Title
+}
+
+// OccupationHierarchy returns the minor, sub-major and major groups that the
+// occupation with code belongs to, from smallest to largest
+func (sdb ScrubberDB) OccupationHierarchy(code string) []Occupation {
+	var hierarchy []Occupation
+
+	for n := len(code) - 1; n > 0; n-- {
+		hierarchy = append(hierarchy, sdb.Occupations.Get(code[:n])...)
+	}
+
+	return hierarchy
+}
+
+func getOccupations(cfg *config.Config) ([]Occupation, error) {
+	file, err := os.Open(cfg.OccupationDataFile)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	rows := []OccupationRow{}
+
+	if err := gocsv.UnmarshalFile(file, &rows); err != nil {
+		return nil, err
+	}
+
+	occupations := make([]Occupation, 0, len(rows))
+
+	for _, row := range rows {
+		for _, code := range []string{row.UnitGroup, row.MinorGroup, row.SubMajorGroup, row.MajorGroup} {
+			if code = strings.TrimSpace(code); code != "" {
+				occupations = append(occupations, Occupation{Code: code, Title: strings.TrimSpace(row.Title)})
+				break
+			}
+		}
+	}
+
+	return occupations, nil
+}
@@ -0,0 +1,55 @@
+package db
+
+import (
+	"os"
+	"testing"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetOccupations(t *testing.T) {
+	data := "Major Group,Sub-Major Group,Minor Group,Unit Group,Group Title\n" +
+		"2,,,,Professional occupations\n" +
+		",22,,,Health professionals\n" +
+		",,225,,Other health professionals\n" +
+		",,, 2253 ,Dental practitioners\n"
+
+	err := os.WriteFile("soc.csv", []byte(data), 0o600)
+	if err != nil {
+		t.Fatalf("Failed to write test data: %v", err)
+	}
+	defer os.Remove("soc.csv")
+
+	occupations, err := getOccupations(&config.Config{OccupationDataFile: "soc.csv"})
+	assert.Nil(t, err)
+
+	assert.Equal(t, []Occupation{
+		{Code: "2", Title: "Professional occupations"},
+		{Code: "22", Title: "Health professionals"},
+		{Code: "225", Title: "Other health professionals"},
+		{Code: "2253", Title: "Dental practitioners"},
+	}, occupations)
+
+	sdb := NewScrubberDB(nil, nil, nil)
+	sdb.Occupations = NewOccupationIndex(occupations)
+
+	assert.Equal(t, []Occupation{
+		{Code: "225", Title: "Other health professionals"},
+		{Code: "22", Title: "Health professionals"},
+		{Code: "2", Title: "Professional occupations"},
+	}, sdb.OccupationHierarchy("2253"))
+
+	assert.Equal(t, LevelUnitGroup, occupations[3].Level())
+	assert.Equal(t, LevelMinorGroup, occupations[2].Level())
+	assert.Equal(t, LevelSubMajorGroup, occupations[1].Level())
+	assert.Equal(t, LevelMajorGroup, occupations[0].Level())
+
+	// only unit groups are found by title
+	titles := NewOccupationTitleIndex(occupations, testStopWords)
+	assert.Equal(t, 1, titles.Len())
+
+	if matches := titles.Find(testStopWords.Terms("dental practitioner in london")); assert.Len(t, matches, 1) {
+		assert.Equal(t, "2253", matches[0].Values[0].Code)
+	}
+}
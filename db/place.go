@@ -0,0 +1,137 @@
+package db
+
+import (
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/config"
+	"github.com/gocarina/gocsv"
+)
+
+// LevelPlace is a town, city or other built-up area, which is found by name
+// and sits outside of the geography hierarchy as it need not fit within it
+const LevelPlace = "place"
+
+// PlaceLookup is a row of the ONS output area to built-up area lookup,
+// linking an output area to the place that contains it
+type PlaceLookup struct {
+	OutputAreaCode     string `csv:"OA11CD"`
+	Code               string `csv:"BUA11CD"`
+	Name               string `csv:"BUA11NM"`
+	LocalAuthorityCode string `csv:"LAD11CD"`
+}
+
+// Place is a town or city of the gazetteer, with the local authorities and
+// output areas that contain it. A place can cross the boundary between local
+// authorities, so it has each of their codes.
+type Place struct {
+	Code                string
+	Name                string
+	LocalAuthorityCodes []string
+	OutputAreaCodes     []string
+}
+
+// AddPlaces adds places to the areas found by name and code, alongside the
+// local authorities and regions of areas. Places in none of the local
+// authorities in areas are left out, and the number added is returned.
+func (sdb *ScrubberDB) AddPlaces(areas []Area, places []Place) int {
+	names := areaNames(areas)
+	added := 0
+
+	for i := range places {
+		placeAreas := sdb.placeAreas(&places[i])
+		if len(placeAreas) == 0 {
+			continue
+		}
+
+		names = append(names, AreaName{Level: LevelPlace, Code: places[i].Code, Name: places[i].Name, Area: placeAreas[0], Areas: placeAreas, Place: &places[i]})
+		added++
+	}
+
+	sdb.AreaNames = NewPhraseIndex(names, areaNameKey, sdb.StopWords)
+	sdb.AreaCodes = NewIndex(names, areaCodeKey)
+
+	return added
+}
+
+// placeAreas returns an area in each local authority of the place, to give
+// the larger areas it belongs to: one of its output areas or, failing that,
+// any area of the local authority. Local authorities not in the data are
+// left out.
+func (sdb *ScrubberDB) placeAreas(place *Place) []*Area {
+	var placeAreas []*Area
+
+	for _, la := range place.LocalAuthorityCodes {
+		if area := sdb.placeAreaIn(place, la); area != nil {
+			placeAreas = append(placeAreas, area)
+		}
+	}
+
+	return placeAreas
+}
+
+func (sdb *ScrubberDB) placeAreaIn(place *Place, la string) *Area {
+	for _, code := range place.OutputAreaCodes {
+		if areas := sdb.Areas.Get(code); len(areas) > 0 && areas[0].LocalAuthorityCode == la {
+			return areas[0]
+		}
+	}
+
+	for _, name := range sdb.AreaCodes.Get(la) {
+		if name.Level == LevelLocalAuthority {
+			return name.Area
+		}
+	}
+
+	return nil
+}
+
+// getPlaces reads the gazetteer, gathering the output areas and local
+// authorities of each place. A place with no output areas can be given by a
+// row with an empty OA11CD.
+func getPlaces(cfg *config.Config) ([]Place, error) {
+	file, err := os.Open(cfg.PlaceDataFile)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	rows := []PlaceLookup{}
+
+	if err := gocsv.UnmarshalFile(file, &rows); err != nil {
+		return nil, err
+	}
+
+	var places []Place
+
+	found := make(map[string]int)
+
+	for _, row := range rows {
+		code := strings.TrimSpace(row.Code)
+		if code == "" {
+			continue
+		}
+
+		i, ok := found[code]
+		if !ok {
+			i = len(places)
+			found[code] = i
+			places = append(places, Place{
+				Code: code,
+				Name: strings.TrimSpace(row.Name),
+			})
+		}
+
+		if la := strings.TrimSpace(row.LocalAuthorityCode); la != "" && !slices.Contains(places[i].LocalAuthorityCodes, la) {
+			places[i].LocalAuthorityCodes = append(places[i].LocalAuthorityCodes, la)
+		}
+
+		if oa := strings.TrimSpace(row.OutputAreaCode); oa != "" {
+			places[i].OutputAreaCodes = append(places[i].OutputAreaCodes, oa)
+		}
+	}
+
+	return places, nil
+}
@@ -0,0 +1,84 @@
+package db
+
+import (
+	"os"
+	"testing"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPlaces(t *testing.T) {
+	err := os.WriteFile("places.csv", []byte("OA11CD,BUA11CD,BUA11NM,LAD11CD\nE00000001,E34000001,Newport,E06000046\n E00000002 ,E34000001,Newport,E06000046\n,W37000001,Newport,W06000022\n,,Nowhere,E06000046\nE00000003,E34000002,Crawley,E07000226\nE00000004,E34000002,Crawley,E07000227\nE00000005,E34000002,Crawley,E07000226\n"), 0o600)
+	if err != nil {
+		t.Fatalf("Failed to write test data: %v", err)
+	}
+	defer os.Remove("places.csv")
+
+	places, err := getPlaces(&config.Config{PlaceDataFile: "places.csv"})
+	assert.Nil(t, err)
+
+	assert.Equal(t, []Place{
+		{Code: "E34000001", Name: "Newport", LocalAuthorityCodes: []string{"E06000046"}, OutputAreaCodes: []string{"E00000001", "E00000002"}},
+		{Code: "W37000001", Name: "Newport", LocalAuthorityCodes: []string{"W06000022"}},
+		{Code: "E34000002", Name: "Crawley", LocalAuthorityCodes: []string{"E07000226", "E07000227"}, OutputAreaCodes: []string{"E00000003", "E00000004", "E00000005"}},
+	}, places)
+}
+
+func TestAddPlaces(t *testing.T) {
+	areas := []Area{
+		{OutputAreaCode: "E00000001", LocalAuthorityCode: "E06000046", LAName: "Isle of Wight", RegionCode: "E12000008", RegionName: "South East"},
+		{OutputAreaCode: "W00000001", LocalAuthorityCode: "W06000022", LAName: "Newport", RegionCode: "W92000004", RegionName: "Wales"},
+	}
+
+	sdb := NewScrubberDB(areas, nil, nil)
+
+	added := sdb.AddPlaces(areas, []Place{
+		{Code: "E34000001", Name: "Newport", LocalAuthorityCodes: []string{"E06000046"}, OutputAreaCodes: []string{"E00000001"}},
+		{Code: "W37000001", Name: "Newport", LocalAuthorityCodes: []string{"W06000022"}},
+		{Code: "E34000002", Name: "Harrogate", LocalAuthorityCodes: []string{"E07000165"}},
+	})
+	assert.Equal(t, 2, added)
+
+	names := sdb.AreaNames.FindWhole([]string{"newport"})
+	if assert.Len(t, names, 3) {
+		assert.Equal(t, LevelLocalAuthority, names[0].Level)
+		assert.Equal(t, LevelPlace, names[1].Level)
+		assert.Equal(t, "Isle of Wight", names[1].Area.LAName)
+		assert.Equal(t, "W06000022", names[2].Area.LocalAuthorityCode)
+		assert.Equal(t, "Newport", names[2].NameIn("cy"))
+	}
+
+	assert.Len(t, sdb.AreaCodes.Get("E34000001"), 1)
+	assert.Len(t, sdb.AreaNames.FindWhole([]string{"isl", "wight"}), 1)
+	assert.Empty(t, sdb.AreaNames.FindWhole([]string{"harrogat"}))
+}
+
+func TestAddPlacesAcrossLocalAuthorities(t *testing.T) {
+	areas := []Area{
+		{OutputAreaCode: "E00000003", LocalAuthorityCode: "E07000226", LAName: "Crawley", RegionCode: "E12000008", RegionName: "South East"},
+		{OutputAreaCode: "E00000004", LocalAuthorityCode: "E07000227", LAName: "Horsham", RegionCode: "E12000008", RegionName: "South East"},
+		{OutputAreaCode: "E00000006", LocalAuthorityCode: "E07000228", LAName: "Mid Sussex", RegionCode: "E12000008", RegionName: "South East"},
+	}
+
+	sdb := NewScrubberDB(areas, nil, nil)
+
+	added := sdb.AddPlaces(areas, []Place{
+		{Code: "E34000002", Name: "Crawley Down", LocalAuthorityCodes: []string{"E07000226", "E07000227", "E07000228", "E07000999"}, OutputAreaCodes: []string{"E00000003", "E00000004"}},
+	})
+	assert.Equal(t, 1, added)
+
+	names := sdb.AreaNames.FindWhole(testStopWords.Terms("Crawley Down"))
+	if assert.Len(t, names, 1) {
+		// the local authority with no output area of the place is given by
+		// one of its own, and the one not in the data is left out
+		assert.Equal(t, []Geography{
+			{Level: LevelPlace, Code: "E34000002", Name: "Crawley Down"},
+			{Level: LevelLocalAuthority, Code: "E07000226", Name: "Crawley"},
+			{Level: LevelLocalAuthority, Code: "E07000227", Name: "Horsham"},
+			{Level: LevelLocalAuthority, Code: "E07000228", Name: "Mid Sussex"},
+			{Level: LevelRegion, Code: "E12000008", Name: "South East"},
+			{Level: LevelCountry, Code: "E92000001", Name: "England", WelshName: "Lloegr"},
+		}, names[0].Hierarchy())
+	}
+}
@@ -0,0 +1,224 @@
+package db
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/config"
+)
+
+// shape types of the polygons in a shapefile, with and without Z and M values
+const (
+	shapeNull     = 0
+	shapePolygon  = 5
+	shapePolygonZ = 15
+	shapePolygonM = 25
+)
+
+// readShapefileBoundaries reads the polygons of a shapefile, taking the output
+// area code of each from the attribute table held in the .dbf file next to it
+// and its projection from the .prj file, if there is one
+func readShapefileBoundaries(cfg *config.Config, add func(code string, rings [][][2]float64)) error {
+	base := strings.TrimSuffix(cfg.BoundaryDataFile, filepath.Ext(cfg.BoundaryDataFile))
+
+	codes, err := readDBFColumn(base+".dbf", cfg.BoundaryCodeProperty)
+	if err != nil {
+		return err
+	}
+
+	proj, err := readPrj(base + ".prj")
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(cfg.BoundaryDataFile)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	r := bufio.NewReader(file)
+
+	header := make([]byte, 100)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return err
+	}
+
+	if binary.BigEndian.Uint32(header[0:4]) != 9994 {
+		return fmt.Errorf("%s is not a shapefile", cfg.BoundaryDataFile)
+	}
+
+	for record := 0; ; record++ {
+		rings, err := readShapefileRecord(r)
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if record < len(codes) && rings != nil {
+			add(codes[record], proj.lonLat(rings))
+		}
+	}
+}
+
+// readPrj returns the projection described by the well-known text of a .prj
+// file, or projectionUnknown if there is no such file
+func readPrj(name string) (projection, error) {
+	data, err := os.ReadFile(name) // #nosec G304 -- the file is set by config
+	if errors.Is(err, fs.ErrNotExist) {
+		return projectionUnknown, nil
+	}
+
+	if err != nil {
+		return projectionUnknown, err
+	}
+
+	wkt := strings.ToUpper(string(data))
+
+	switch {
+	case strings.Contains(wkt, "BRITISH_NATIONAL_GRID"), strings.Contains(wkt, "BRITISH NATIONAL GRID"):
+		return projectionBNG, nil
+	case strings.HasPrefix(strings.TrimSpace(wkt), "GEOGCS") && strings.Contains(wkt, "WGS"):
+		return projectionWGS84, nil
+	default:
+		return projectionUnknown, fmt.Errorf("unsupported projection in %s, expected WGS84 or British National Grid", name)
+	}
+}
+
+// readShapefileRecord returns the rings of the next record, or nil rings if it
+// is a null shape
+func readShapefileRecord(r io.Reader) ([][][2]float64, error) {
+	recordHeader := make([]byte, 8)
+	if _, err := io.ReadFull(r, recordHeader); err != nil {
+		return nil, err
+	}
+
+	// content length is given in 16-bit words
+	content := make([]byte, int(binary.BigEndian.Uint32(recordHeader[4:8]))*2)
+	if _, err := io.ReadFull(r, content); err != nil {
+		return nil, err
+	}
+
+	if len(content) < 4 {
+		return nil, fmt.Errorf("invalid shapefile record")
+	}
+
+	switch shapeType := binary.LittleEndian.Uint32(content[0:4]); shapeType {
+	case shapeNull:
+		return nil, nil
+	case shapePolygon, shapePolygonZ, shapePolygonM:
+		return parseShapefilePolygon(content)
+	default:
+		return nil, fmt.Errorf("unsupported shape type %d, expected polygons", shapeType)
+	}
+}
+
+// parseShapefilePolygon reads the parts of a polygon record, skipping its
+// bounding box and any Z or M values after its points
+func parseShapefilePolygon(content []byte) ([][][2]float64, error) {
+	if len(content) < 44 {
+		return nil, fmt.Errorf("invalid shapefile polygon")
+	}
+
+	numParts := int(binary.LittleEndian.Uint32(content[36:40]))
+	numPoints := int(binary.LittleEndian.Uint32(content[40:44]))
+
+	partsStart := 44
+	pointsStart := partsStart + numParts*4
+
+	if numParts < 0 || numPoints < 0 || len(content) < pointsStart+numPoints*16 {
+		return nil, fmt.Errorf("invalid shapefile polygon")
+	}
+
+	rings := make([][][2]float64, 0, numParts)
+
+	for part := 0; part < numParts; part++ {
+		start := int(binary.LittleEndian.Uint32(content[partsStart+part*4:]))
+
+		end := numPoints
+		if part < numParts-1 {
+			end = int(binary.LittleEndian.Uint32(content[partsStart+(part+1)*4:]))
+		}
+
+		if start < 0 || start > end || end > numPoints {
+			return nil, fmt.Errorf("invalid shapefile polygon part")
+		}
+
+		ring := make([][2]float64, 0, end-start)
+
+		for p := start; p < end; p++ {
+			offset := pointsStart + p*16
+			ring = append(ring, [2]float64{
+				math.Float64frombits(binary.LittleEndian.Uint64(content[offset:])),
+				math.Float64frombits(binary.LittleEndian.Uint64(content[offset+8:])),
+			})
+		}
+
+		rings = append(rings, ring)
+	}
+
+	return rings, nil
+}
+
+// readDBFColumn returns the values of one column of a dBase table, in record
+// order
+func readDBFColumn(name, column string) ([]string, error) {
+	data, err := os.ReadFile(name) // #nosec G304 -- the file is set by config
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < 32 {
+		return nil, fmt.Errorf("%s is not a dBase file", name)
+	}
+
+	numRecords := int(binary.LittleEndian.Uint32(data[4:8]))
+	headerLen := int(binary.LittleEndian.Uint16(data[8:10]))
+	recordLen := int(binary.LittleEndian.Uint16(data[10:12]))
+
+	// field descriptors follow the header until a 0x0D terminator, each
+	// field starting after the previous one and the deletion flag
+	offset, width := 1, -1
+
+	for desc := 32; desc+32 <= len(data) && data[desc] != 0x0D; desc += 32 {
+		fieldName := string(bytes.TrimRight(data[desc:desc+11], "\x00"))
+		fieldLen := int(data[desc+16])
+
+		if strings.EqualFold(fieldName, column) {
+			width = fieldLen
+			break
+		}
+
+		offset += fieldLen
+	}
+
+	if width < 0 {
+		return nil, fmt.Errorf("column %q not found in %s", column, name)
+	}
+
+	values := make([]string, 0, numRecords)
+
+	for record := 0; record < numRecords; record++ {
+		start := headerLen + record*recordLen + offset
+		if start+width > len(data) {
+			return nil, fmt.Errorf("%s is shorter than its header says", name)
+		}
+
+		values = append(values, strings.TrimSpace(string(data[start:start+width])))
+	}
+
+	return values, nil
+}
@@ -0,0 +1,350 @@
+package db
+
+import (
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/config"
+)
+
+// snapshotVersion is a hash of the layout of snapshot, so that a snapshot
+// written by a build whose types differ in any field is rebuilt rather than
+// decoded with that field missing
+var snapshotVersion = typeHash(reflect.TypeOf(snapshot{}))
+
+// snapshot is the serialised form of the parsed CSV data, and of the indexes
+// of the areas that take longest to build
+type snapshot struct {
+	Version    string
+	SourceHash string
+	Areas      []Area
+	Industries []Industry
+	// the indexes of Areas by output area, LSOA and MSOA code, and the names
+	// of their local authorities and regions, refer to each area by its
+	// position in Areas
+	AreaIndex, LSOAIndex, MSOAIndex snapshotIndex
+	AreaNames                       []snapshotAreaName
+}
+
+// snapshotIndex is the serialised form of an Index of areas
+type snapshotIndex struct {
+	Labels []string
+	// Nodes holds the children, nChildren, lo, mid and hi of each node
+	Nodes  [][5]int32
+	Values []int32
+}
+
+// snapshotAreaName is the serialised form of an AreaName
+type snapshotAreaName struct {
+	Level, Code, Name string
+	Area              int32
+}
+
+// BuildSnapshot parses the configured CSV files and writes them to
+// cfg.SnapshotFile, tagged with a hash of the files it was built from
+func BuildSnapshot(cfg *config.Config) error {
+	if cfg.SnapshotFile == "" {
+		return fmt.Errorf("no snapshot file configured")
+	}
+
+	hash, err := sourceHash(cfg)
+	if err != nil {
+		return err
+	}
+
+	areaData, err := getArea(cfg)
+	if err != nil {
+		return err
+	}
+
+	// unlike at startup, a snapshot is not built without an optional file
+	for _, extra := range optionalAreaFiles(cfg) {
+		more, err := readAreaFile(extra.file)
+		if err != nil {
+			return fmt.Errorf("%s area data: %w", extra.name, err)
+		}
+
+		areaData = append(areaData, more...)
+	}
+
+	for _, extra := range areaExtras(cfg) {
+		if err := extra.add(cfg, areaData); err != nil {
+			return err
+		}
+	}
+
+	industryData, err := getIndustry(cfg)
+	if err != nil {
+		return err
+	}
+
+	if cfg.IndustryWelshDataFile != "" {
+		if err := loadWelshIndustries(cfg, industryData); err != nil {
+			return err
+		}
+	}
+
+	snap := snapshot{
+		Version:    snapshotVersion,
+		SourceHash: hash,
+		Areas:      areaData,
+		Industries: industryData,
+	}
+
+	snap.setAreaIndexes(buildAreaIndexes(areaData))
+
+	file, err := os.Create(cfg.SnapshotFile)
+	if err != nil {
+		return err
+	}
+
+	if err := gob.NewEncoder(file).Encode(snap); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// loadSnapshot reads cfg.SnapshotFile and returns its contents, as long as it
+// was built by this version from the CSV files currently configured
+func loadSnapshot(cfg *config.Config) (*snapshot, error) {
+	file, err := os.Open(cfg.SnapshotFile)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	var snap snapshot
+
+	if err := gob.NewDecoder(file).Decode(&snap); err != nil {
+		return nil, err
+	}
+
+	if snap.Version != snapshotVersion {
+		return nil, fmt.Errorf("snapshot version %s does not match expected version %s", snap.Version, snapshotVersion)
+	}
+
+	hash, err := sourceHash(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if snap.SourceHash != hash {
+		return nil, fmt.Errorf("snapshot is out of date with the configured data files")
+	}
+
+	return &snap, nil
+}
+
+// setAreaIndexes holds indexes in the snapshot, giving each area by its
+// position in snap.Areas
+func (snap *snapshot) setAreaIndexes(indexes areaIndexes) {
+	positions := make(map[*Area]int32, len(snap.Areas))
+	for i := range snap.Areas {
+		positions[&snap.Areas[i]] = int32(i) // #nosec G115 -- bounded by the number of output areas
+	}
+
+	snap.AreaIndex = newSnapshotIndex(indexes.areas, positions)
+	snap.LSOAIndex = newSnapshotIndex(indexes.lsoas, positions)
+	snap.MSOAIndex = newSnapshotIndex(indexes.msoas, positions)
+
+	snap.AreaNames = make([]snapshotAreaName, 0, len(indexes.names))
+	for _, name := range indexes.names {
+		snap.AreaNames = append(snap.AreaNames, snapshotAreaName{Level: name.Level, Code: name.Code, Name: name.Name, Area: positions[name.Area]})
+	}
+}
+
+// areaIndexes returns the indexes held in the snapshot, or an error if they
+// refer to areas or nodes it does not have
+func (snap *snapshot) areaIndexes() (*areaIndexes, error) {
+	var (
+		indexes areaIndexes
+		err     error
+	)
+
+	if indexes.areas, err = snap.AreaIndex.index(snap.Areas); err != nil {
+		return nil, err
+	}
+
+	if indexes.lsoas, err = snap.LSOAIndex.index(snap.Areas); err != nil {
+		return nil, err
+	}
+
+	if indexes.msoas, err = snap.MSOAIndex.index(snap.Areas); err != nil {
+		return nil, err
+	}
+
+	indexes.names = make([]AreaName, 0, len(snap.AreaNames))
+	for _, name := range snap.AreaNames {
+		if name.Area < 0 || int(name.Area) >= len(snap.Areas) {
+			return nil, errCorruptSnapshot
+		}
+
+		indexes.names = append(indexes.names, AreaName{Level: name.Level, Code: name.Code, Name: name.Name, Area: &snap.Areas[name.Area]})
+	}
+
+	return &indexes, nil
+}
+
+var errCorruptSnapshot = errors.New("snapshot index refers to data it does not hold")
+
+func newSnapshotIndex(idx *Index[*Area], positions map[*Area]int32) snapshotIndex {
+	s := snapshotIndex{
+		Labels: make([]string, len(idx.nodes)),
+		Nodes:  make([][5]int32, len(idx.nodes)),
+		Values: make([]int32, len(idx.values)),
+	}
+
+	for i, node := range idx.nodes {
+		s.Labels[i] = node.label
+		s.Nodes[i] = [5]int32{node.children, node.nChildren, node.lo, node.mid, node.hi}
+	}
+
+	for i, area := range idx.values {
+		s.Values[i] = positions[area]
+	}
+
+	return s
+}
+
+// index returns the Index held by s, its values being areas. The nodes are
+// checked so that a damaged snapshot cannot make a lookup go out of range.
+func (s snapshotIndex) index(areas []Area) (*Index[*Area], error) {
+	if len(s.Nodes) == 0 || len(s.Labels) != len(s.Nodes) {
+		return nil, errCorruptSnapshot
+	}
+
+	idx := &Index[*Area]{
+		nodes:  make([]indexNode, len(s.Nodes)),
+		values: make([]*Area, len(s.Values)),
+	}
+
+	for i, position := range s.Values {
+		if position < 0 || int(position) >= len(areas) {
+			return nil, errCorruptSnapshot
+		}
+
+		idx.values[i] = &areas[position]
+	}
+
+	for i, n := range s.Nodes {
+		children, nChildren, lo, mid, hi := n[0], n[1], n[2], n[3], n[4]
+
+		if nChildren < 0 || (nChildren > 0 && (children <= 0 || int(children)+int(nChildren) > len(s.Nodes))) ||
+			lo < 0 || lo > mid || mid > hi || int(hi) > len(s.Values) || (i > 0 && s.Labels[i] == "") {
+			return nil, errCorruptSnapshot
+		}
+
+		idx.nodes[i] = indexNode{label: s.Labels[i], children: children, nChildren: nChildren, lo: lo, mid: mid, hi: hi}
+	}
+
+	return idx, nil
+}
+
+// typeHash returns a hash of the names and types of the fields gob encodes
+// for t, following them into the types they hold
+func typeHash(t reflect.Type) string {
+	var b strings.Builder
+
+	describeType(&b, t, make(map[reflect.Type]bool))
+
+	h := sha256.Sum256([]byte(b.String()))
+
+	return hex.EncodeToString(h[:8])
+}
+
+func describeType(b *strings.Builder, t reflect.Type, seen map[reflect.Type]bool) {
+	switch t.Kind() {
+	case reflect.Pointer:
+		describeType(b, t.Elem(), seen)
+	case reflect.Slice, reflect.Array:
+		b.WriteString("[]")
+		describeType(b, t.Elem(), seen)
+	case reflect.Map:
+		b.WriteString("map[")
+		describeType(b, t.Key(), seen)
+		b.WriteString("]")
+		describeType(b, t.Elem(), seen)
+	case reflect.Struct:
+		// a type holding itself is described by name the second time
+		if seen[t] {
+			b.WriteString(t.Name())
+			return
+		}
+
+		seen[t] = true
+
+		b.WriteString("struct{")
+
+		for i := 0; i < t.NumField(); i++ {
+			if field := t.Field(i); field.IsExported() {
+				b.WriteString(field.Name + " ")
+				describeType(b, field.Type, seen)
+				b.WriteString(";")
+			}
+		}
+
+		b.WriteString("}")
+	default:
+		b.WriteString(t.Kind().String())
+	}
+}
+
+// sourceHash returns a hash of the contents of every file a snapshot is built from
+func sourceHash(cfg *config.Config) (string, error) {
+	h := sha256.New()
+
+	// hash each file on its own so that moving bytes from one file to the
+	// next still changes the result
+	for _, name := range sourceFiles(cfg) {
+		fileHash, err := hashFile(name)
+		if err != nil {
+			return "", err
+		}
+
+		h.Write(fileHash)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// sourceFiles returns every configured file that a snapshot is built from
+func sourceFiles(cfg *config.Config) []string {
+	files := append(areaDataFiles(cfg), cfg.IndustryDataFile)
+
+	for _, extra := range areaExtras(cfg) {
+		files = append(files, extra.file)
+	}
+
+	if cfg.IndustryWelshDataFile != "" {
+		files = append(files, cfg.IndustryWelshDataFile)
+	}
+
+	return files
+}
+
+func hashFile(name string) ([]byte, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	h := sha256.New()
+
+	if _, err := io.Copy(h, file); err != nil {
+		return nil, err
+	}
+
+	return h.Sum(nil), nil
+}
@@ -0,0 +1,276 @@
+package db
+
+import (
+	"context"
+	"encoding/gob"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/config"
+	"github.com/ONSdigital/dp-search-scrubber-api/db/mock"
+	"github.com/gocarina/gocsv"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildAndLoadSnapshot(t *testing.T) {
+	m := mock.CreateFiles(t)
+	defer m.CloseFiles()
+
+	cfg := config.Config{
+		AreaDataFile:     "area.csv",
+		IndustryDataFile: "industry.csv",
+		SnapshotFile:     "test.snapshot",
+	}
+	defer os.Remove(cfg.SnapshotFile)
+
+	err := BuildSnapshot(&cfg)
+	assert.Nil(t, err)
+
+	snap, err := loadSnapshot(&cfg)
+	assert.Nil(t, err)
+	assert.Equal(t, snapshotVersion, snap.Version)
+	assert.Len(t, snap.Areas, 2)
+	assert.Equal(t, "Test Output Area Code1", snap.Areas[0].OutputAreaCode)
+	assert.Len(t, snap.Industries, 2)
+	assert.Equal(t, "TestCode2", snap.Industries[1].Code)
+
+	// the indexes are loaded ready built, pointing into the loaded areas
+	indexes, err := snap.areaIndexes()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, indexes.areas.Len())
+	assert.Same(t, &snap.Areas[1], indexes.areas.Get("Test Output Area Code2")[0])
+	assert.Len(t, indexes.areas.GetByPrefix("Test Output Area"), 2)
+	assert.Equal(t, 0, indexes.lsoas.Len())
+	assert.Len(t, indexes.names, 4)
+	assert.Same(t, &snap.Areas[0], indexes.names[0].Area)
+
+	_, areasOfSnapshot, loaded := loadData(context.Background(), &cfg)
+	assert.Len(t, areasOfSnapshot, 2)
+	assert.NotNil(t, loaded)
+}
+
+func TestSnapshotIndexRejectsDamage(t *testing.T) {
+	areas := []Area{{OutputAreaCode: "E00000001"}, {OutputAreaCode: "E00000002"}}
+
+	snap := snapshot{Areas: areas}
+	snap.setAreaIndexes(buildAreaIndexes(areas))
+
+	idx, err := snap.AreaIndex.index(areas)
+	assert.Nil(t, err)
+	assert.Same(t, &areas[1], idx.Get("E00000002")[0])
+
+	// a value after the last area
+	damaged := snap.AreaIndex
+	damaged.Values = []int32{0, 2}
+	_, err = damaged.index(areas)
+	assert.NotNil(t, err)
+
+	// children after the last node
+	damaged = snap.AreaIndex
+	damaged.Nodes = slices.Clone(damaged.Nodes)
+	damaged.Nodes[0][1] = int32(len(damaged.Nodes))
+	_, err = damaged.index(areas)
+	assert.NotNil(t, err)
+
+	// values after the last value
+	damaged = snap.AreaIndex
+	damaged.Nodes = slices.Clone(damaged.Nodes)
+	damaged.Nodes[0][4] = int32(len(damaged.Values) + 1)
+	_, err = damaged.index(areas)
+	assert.NotNil(t, err)
+
+	_, err = snapshotIndex{}.index(areas)
+	assert.NotNil(t, err)
+}
+
+func TestLoadSnapshotRejectsChangedData(t *testing.T) {
+	m := mock.CreateFiles(t)
+	defer m.CloseFiles()
+
+	cfg := config.Config{
+		AreaDataFile:     "area.csv",
+		IndustryDataFile: "industry.csv",
+		SnapshotFile:     "test.snapshot",
+	}
+	defer os.Remove(cfg.SnapshotFile)
+
+	err := BuildSnapshot(&cfg)
+	assert.Nil(t, err)
+
+	err = os.WriteFile("industry.csv", []byte("SIC Code,Description\nTestCode3,TestName3\n"), 0o600)
+	assert.Nil(t, err)
+
+	snap, err := loadSnapshot(&cfg)
+	assert.Nil(t, snap)
+	assert.NotNil(t, err)
+
+	// the CSV files are used instead of the stale snapshot
+	areas, industries, indexes := loadData(context.Background(), &cfg)
+	assert.Nil(t, indexes)
+	assert.Len(t, areas, 2)
+	assert.Len(t, industries, 1)
+	assert.Equal(t, "TestCode3", industries[0].Code)
+}
+
+func TestLoadSnapshotRejectsOtherVersion(t *testing.T) {
+	m := mock.CreateFiles(t)
+	defer m.CloseFiles()
+
+	cfg := config.Config{
+		AreaDataFile:     "area.csv",
+		IndustryDataFile: "industry.csv",
+		SnapshotFile:     "test.snapshot",
+	}
+	defer os.Remove(cfg.SnapshotFile)
+
+	hash, err := sourceHash(&cfg)
+	assert.Nil(t, err)
+
+	file, err := os.Create(cfg.SnapshotFile)
+	assert.Nil(t, err)
+
+	err = gob.NewEncoder(file).Encode(snapshot{Version: "older", SourceHash: hash})
+	assert.Nil(t, err)
+	file.Close()
+
+	snap, err := loadSnapshot(&cfg)
+	assert.Nil(t, snap)
+	assert.NotNil(t, err)
+}
+
+func TestTypeHash(t *testing.T) {
+	type area struct {
+		Code string
+		Name string
+	}
+
+	type renamed struct {
+		Code  string
+		Label string
+	}
+
+	type retyped struct {
+		Code int
+		Name string
+	}
+
+	type extra struct {
+		Code  string
+		Name  string
+		Welsh string
+	}
+
+	type unexported struct {
+		Code string
+		Name string
+		seen bool
+	}
+
+	hash := typeHash(reflect.TypeOf(struct{ Areas []area }{}))
+
+	assert.Equal(t, hash, typeHash(reflect.TypeOf(struct{ Areas []area }{})))
+	assert.Equal(t, hash, typeHash(reflect.TypeOf(struct{ Areas []unexported }{})))
+	assert.NotEqual(t, hash, typeHash(reflect.TypeOf(struct{ Areas []renamed }{})))
+	assert.NotEqual(t, hash, typeHash(reflect.TypeOf(struct{ Areas []retyped }{})))
+	assert.NotEqual(t, hash, typeHash(reflect.TypeOf(struct{ Areas []extra }{})))
+	assert.NotEqual(t, hash, typeHash(reflect.TypeOf(struct{ Areas map[string]area }{})))
+}
+
+func TestBuildSnapshotWithoutFile(t *testing.T) {
+	err := BuildSnapshot(&config.Config{})
+	assert.NotNil(t, err)
+}
+
+// BenchmarkStartup compares starting up from the production sized area and
+// industry CSV files with starting up from a snapshot, each ending with the
+// same indexes ready to search
+func BenchmarkStartup(b *testing.B) {
+	dir := b.TempDir()
+
+	areas := benchmarkAreas(b)
+
+	// each output area is given an LSOA and MSOA, as the ONS lookup does
+	lookups := make([]AreaLookup, 0, len(areas))
+	for i, area := range areas {
+		lookups = append(lookups, AreaLookup{
+			OutputAreaCode: area.OutputAreaCode,
+			LSOACode:       fmt.Sprintf("E%08d", 1000000+i/5),
+			LSOAName:       fmt.Sprintf("%s %04d", area.LAName, i/5),
+			MSOACode:       fmt.Sprintf("E%08d", 2000000+i/25),
+			MSOAName:       fmt.Sprintf("%s %03d", area.LAName, i/25),
+		})
+	}
+
+	cfg := &config.Config{
+		AreaDataFile:     filepath.Join(dir, "areas.csv"),
+		AreaLookupFile:   filepath.Join(dir, "lookup.csv"),
+		IndustryDataFile: "../data/SIC07_CH_condensed_list_en.csv",
+		SnapshotFile:     filepath.Join(dir, "scrubber.snapshot"),
+	}
+
+	writeBenchmarkCSV(b, cfg.AreaDataFile, areas)
+	writeBenchmarkCSV(b, cfg.AreaLookupFile, lookups)
+
+	if err := BuildSnapshot(cfg); err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("csv", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			areas, err := getArea(cfg)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			if err := loadLookup(cfg, areas); err != nil {
+				b.Fatal(err)
+			}
+
+			industries, err := getIndustry(cfg)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			internAreas(areas)
+			NewScrubberDB(areas, industries, nil)
+		}
+	})
+
+	b.Run("snapshot", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			snap, err := loadSnapshot(cfg)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			indexes, err := snap.areaIndexes()
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			internAreas(snap.Areas)
+			newScrubberDB(snap.Areas, snap.Industries, nil, *indexes)
+		}
+	})
+}
+
+func writeBenchmarkCSV(b *testing.B, name string, rows any) {
+	file, err := os.Create(name)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	defer file.Close()
+
+	if err := gocsv.MarshalFile(rows, file); err != nil {
+		b.Fatal(err)
+	}
+}
@@ -0,0 +1,195 @@
+package db
+
+import (
+	"math"
+	"slices"
+)
+
+// earthRadius is the mean radius of the earth in metres
+const earthRadius = 6371008.8
+
+// PointIndex is an immutable 2-d tree of areas over the latitude and
+// longitude of their centroids.
+//
+// The tree is stored implicitly: the root of each range of points is the
+// middle point of the range, splitting on latitude at even depths and on
+// longitude at odd depths.
+type PointIndex struct {
+	points []*Area
+}
+
+// NewPointIndex builds a PointIndex of every area with a centroid
+func NewPointIndex(areas []Area) *PointIndex {
+	points := make([]*Area, 0, len(areas))
+
+	for i := range areas {
+		if areas[i].HasCentroid() {
+			points = append(points, &areas[i])
+		}
+	}
+
+	buildPoints(points, 0)
+
+	return &PointIndex{points: points}
+}
+
+func buildPoints(points []*Area, depth int) {
+	if len(points) <= 1 {
+		return
+	}
+
+	slices.SortFunc(points, func(a, b *Area) int {
+		if depth%2 == 0 {
+			return cmpFloat(a.Latitude, b.Latitude)
+		}
+
+		return cmpFloat(a.Longitude, b.Longitude)
+	})
+
+	mid := len(points) / 2
+
+	buildPoints(points[:mid], depth+1)
+	buildPoints(points[mid+1:], depth+1)
+}
+
+func cmpFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Len returns the number of areas held in the index
+func (pi *PointIndex) Len() int {
+	return len(pi.points)
+}
+
+// Nearest returns the area whose centroid is nearest to lat, lon, or nil if
+// the index is empty
+func (pi *PointIndex) Nearest(lat, lon float64) *Area {
+	s := nearestSearch{
+		lat:    lat,
+		lon:    lon,
+		lonCos: math.Cos(lat * math.Pi / 180),
+		best:   math.Inf(1),
+	}
+
+	s.search(pi.points, 0)
+
+	return s.area
+}
+
+// nearestSearch finds the nearest point by the distance across a flat map
+// scaled to the latitude being searched from, which matches the distance over
+// the earth closely at the scale of output areas
+type nearestSearch struct {
+	lat, lon, lonCos float64
+	best             float64
+	area             *Area
+}
+
+func (s *nearestSearch) search(points []*Area, depth int) {
+	if len(points) == 0 {
+		return
+	}
+
+	mid := len(points) / 2
+	p := points[mid]
+
+	dLat, dLon := p.Latitude-s.lat, (p.Longitude-s.lon)*s.lonCos
+	if d := dLat*dLat + dLon*dLon; d < s.best {
+		s.best, s.area = d, p
+	}
+
+	// distance from the point being searched from to the split
+	split := dLat
+	if depth%2 == 1 {
+		split = dLon
+	}
+
+	near, far := points[:mid], points[mid+1:]
+	if split < 0 {
+		near, far = far, near
+	}
+
+	s.search(near, depth+1)
+
+	if split*split < s.best {
+		s.search(far, depth+1)
+	}
+}
+
+// WithinBox returns every area whose centroid is inside the box between the
+// given corners
+func (pi *PointIndex) WithinBox(minLat, minLon, maxLat, maxLon float64) []*Area {
+	var areas []*Area
+
+	var search func(points []*Area, depth int)
+	search = func(points []*Area, depth int) {
+		if len(points) == 0 {
+			return
+		}
+
+		mid := len(points) / 2
+		p := points[mid]
+
+		if p.Latitude >= minLat && p.Latitude <= maxLat && p.Longitude >= minLon && p.Longitude <= maxLon {
+			areas = append(areas, p)
+		}
+
+		v, lo, hi := p.Latitude, minLat, maxLat
+		if depth%2 == 1 {
+			v, lo, hi = p.Longitude, minLon, maxLon
+		}
+
+		if lo <= v {
+			search(points[:mid], depth+1)
+		}
+
+		if hi >= v {
+			search(points[mid+1:], depth+1)
+		}
+	}
+
+	search(pi.points, 0)
+
+	return areas
+}
+
+// WithinRadius returns every area whose centroid is within radius metres of
+// lat, lon
+func (pi *PointIndex) WithinRadius(lat, lon, radius float64) []*Area {
+	dLat := radius / earthRadius * 180 / math.Pi
+
+	// longitude degrees are shortest at the edge of the box furthest from
+	// the equator
+	maxAbsLat := math.Min(math.Abs(lat)+dLat, 89.9)
+	dLon := dLat / math.Cos(maxAbsLat*math.Pi/180)
+
+	candidates := pi.WithinBox(lat-dLat, lon-dLon, lat+dLat, lon+dLon)
+
+	areas := candidates[:0]
+	for _, area := range candidates {
+		if Distance(lat, lon, area.Latitude, area.Longitude) <= radius {
+			areas = append(areas, area)
+		}
+	}
+
+	return areas
+}
+
+// Distance returns the great circle distance in metres between two points
+func Distance(lat1, lon1, lat2, lon2 float64) float64 {
+	const rad = math.Pi / 180
+
+	dLat := (lat2 - lat1) * rad
+	dLon := (lon2 - lon1) * rad
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1*rad)*math.Cos(lat2*rad)*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
@@ -0,0 +1,110 @@
+package db
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// randomAreas returns areas spread over roughly the extent of Great Britain
+func randomAreas(n int) []Area {
+	r := rand.New(rand.NewSource(1)) // #nosec G404 -- repeatable test data
+
+	areas := make([]Area, n)
+	for i := range areas {
+		areas[i] = Area{
+			OutputAreaCode: string(rune('A'+i%26)) + string(rune('A'+i/26%26)) + string(rune('A'+i/676)),
+			Latitude:       49.9 + r.Float64()*10.9,
+			Longitude:      -8.2 + r.Float64()*10,
+		}
+	}
+
+	return areas
+}
+
+func codesOf(areas []*Area) []string {
+	codes := make([]string, 0, len(areas))
+	for _, area := range areas {
+		codes = append(codes, area.OutputAreaCode)
+	}
+
+	sort.Strings(codes)
+
+	return codes
+}
+
+func TestPointIndexNearest(t *testing.T) {
+	areas := randomAreas(2000)
+	idx := NewPointIndex(areas)
+
+	for _, q := range randomAreas(200) {
+		var expected *Area
+
+		for i := range areas {
+			if expected == nil || Distance(q.Latitude, q.Longitude, areas[i].Latitude, areas[i].Longitude) <
+				Distance(q.Latitude, q.Longitude, expected.Latitude, expected.Longitude) {
+				expected = &areas[i]
+			}
+		}
+
+		assert.Equal(t, expected.OutputAreaCode, idx.Nearest(q.Latitude+0.001, q.Longitude-0.001).OutputAreaCode)
+	}
+}
+
+func TestPointIndexWithinBox(t *testing.T) {
+	areas := randomAreas(2000)
+	idx := NewPointIndex(areas)
+
+	var expected []*Area
+
+	for i := range areas {
+		if areas[i].Latitude >= 51 && areas[i].Latitude <= 53 && areas[i].Longitude >= -2 && areas[i].Longitude <= 0.5 {
+			expected = append(expected, &areas[i])
+		}
+	}
+
+	assert.NotEmpty(t, expected)
+	assert.Equal(t, codesOf(expected), codesOf(idx.WithinBox(51, -2, 53, 0.5)))
+}
+
+func TestPointIndexWithinRadius(t *testing.T) {
+	areas := randomAreas(2000)
+	idx := NewPointIndex(areas)
+
+	var expected []*Area
+
+	for i := range areas {
+		if Distance(57, -3, areas[i].Latitude, areas[i].Longitude) <= 100000 {
+			expected = append(expected, &areas[i])
+		}
+	}
+
+	assert.NotEmpty(t, expected)
+	assert.Equal(t, codesOf(expected), codesOf(idx.WithinRadius(57, -3, 100000)))
+}
+
+func TestPointIndexSkipsAreasWithoutCentroids(t *testing.T) {
+	idx := NewPointIndex([]Area{
+		{OutputAreaCode: "E00000001"},
+		{OutputAreaCode: "E00000002", Latitude: 51.5, Longitude: -0.1},
+	})
+
+	assert.Equal(t, 1, idx.Len())
+	assert.Equal(t, "E00000002", idx.Nearest(0, 0).OutputAreaCode)
+}
+
+func TestEmptyPointIndex(t *testing.T) {
+	idx := NewPointIndex(nil)
+
+	assert.Equal(t, 0, idx.Len())
+	assert.Nil(t, idx.Nearest(51.5, -0.1))
+	assert.Empty(t, idx.WithinRadius(51.5, -0.1, 1000))
+}
+
+func TestDistance(t *testing.T) {
+	// London to Edinburgh
+	assert.InDelta(t, 534000, Distance(51.5074, -0.1278, 55.9533, -3.1883), 2000)
+	assert.Equal(t, 0.0, Distance(51.5, -0.1, 51.5, -0.1))
+}
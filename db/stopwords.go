@@ -0,0 +1,44 @@
+package db
+
+import (
+	"os"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/config"
+	"github.com/ONSdigital/dp-search-scrubber-api/text"
+	"github.com/gocarina/gocsv"
+)
+
+// StopWord is a word of a language that is too common to be worth searching
+// for, such as "the" in English
+type StopWord struct {
+	Language string `csv:"Language"`
+	Word     string `csv:"Word"`
+}
+
+// NewStopWords collects stopWords by language
+func NewStopWords(stopWords []StopWord) text.StopWords {
+	pairs := make([][2]string, 0, len(stopWords))
+
+	for _, sw := range stopWords {
+		pairs = append(pairs, [2]string{sw.Language, sw.Word})
+	}
+
+	return text.NewStopWords(pairs)
+}
+
+func getStopWords(cfg *config.Config) ([]StopWord, error) {
+	file, err := os.Open(cfg.StopWordFile)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	stopWords := []StopWord{}
+
+	if err := gocsv.UnmarshalFile(file, &stopWords); err != nil {
+		return nil, err
+	}
+
+	return stopWords, nil
+}
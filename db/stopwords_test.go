@@ -0,0 +1,33 @@
+package db
+
+import (
+	"os"
+	"testing"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetStopWords(t *testing.T) {
+	err := os.WriteFile("stopwords.csv", []byte("Language,Word\nen,the\nen,Near\ncy,yng\nen,IT\n"), 0o600)
+	if err != nil {
+		t.Fatalf("Failed to write test data: %v", err)
+	}
+	defer os.Remove("stopwords.csv")
+
+	stopWords, err := getStopWords(&config.Config{StopWordFile: "stopwords.csv"})
+	assert.Nil(t, err)
+
+	assert.Equal(t, []StopWord{
+		{Language: "en", Word: "the"},
+		{Language: "en", Word: "Near"},
+		{Language: "cy", Word: "yng"},
+		{Language: "en", Word: "IT"},
+	}, stopWords)
+
+	sw := NewStopWords(stopWords)
+	assert.True(t, sw.Contains("en", "near"))
+	assert.True(t, sw.Contains("cy", "yng"))
+	assert.False(t, sw.Contains("en", "dentists"))
+	assert.True(t, sw.Keeps("en", "IT"))
+}
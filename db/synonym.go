@@ -0,0 +1,56 @@
+package db
+
+import (
+	"os"
+	"strings"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/config"
+	"github.com/ONSdigital/dp-search-scrubber-api/text"
+	"github.com/gocarina/gocsv"
+)
+
+// IndustrySynonym is an entry of the SIC alphabetical index, an everyday
+// term for a job or business and the SIC code it is classified to
+type IndustrySynonym struct {
+	Code string `csv:"SIC2007"`
+	Term string `csv:"Activity"`
+}
+
+// NewSynonymIndex indexes synonyms by their term, leaving out stopWords and
+// the synonyms that have no SIC code
+func NewSynonymIndex(synonyms []IndustrySynonym, stopWords text.StopWords) *PhraseIndex[IndustrySynonym] {
+	coded := make([]IndustrySynonym, 0, len(synonyms))
+
+	for _, synonym := range synonyms {
+		if synonym.Code != "" {
+			coded = append(coded, synonym)
+		}
+	}
+
+	return NewPhraseIndex(coded, synonymKey, stopWords)
+}
+
+func synonymKey(synonym IndustrySynonym) string {
+	return synonym.Term
+}
+
+func getIndustrySynonyms(cfg *config.Config) ([]IndustrySynonym, error) {
+	file, err := os.Open(cfg.IndustrySynonymFile)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	synonyms := []IndustrySynonym{}
+
+	if err := gocsv.UnmarshalFile(file, &synonyms); err != nil {
+		return nil, err
+	}
+
+	for i := range synonyms {
+		synonyms[i].Code = strings.TrimSpace(synonyms[i].Code)
+	}
+
+	return synonyms, nil
+}
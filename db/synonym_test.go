@@ -0,0 +1,55 @@
+package db
+
+import (
+	"os"
+	"testing"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSynonymIndex(t *testing.T) {
+	si := NewSynonymIndex([]IndustrySynonym{
+		{Code: "10710", Term: "Baker"},
+		{Code: "47240", Term: "Bakery"},
+		{Code: "86230", Term: "Dental surgeon"},
+		{Code: "", Term: "Unclassified"},
+		{Code: "01110", Term: "  "},
+	}, testStopWords)
+
+	assert.Equal(t, 3, si.Len())
+
+	// every form of the word matches both bakers
+	for _, q := range []string{"bakers", "bakery", "baking", "Bakeries"} {
+		t.Run(q, func(t *testing.T) {
+			matches := si.Find(testStopWords.Terms(q))
+
+			if assert.Len(t, matches, 1) {
+				var codes []string
+				for _, synonym := range matches[0].Values {
+					codes = append(codes, synonym.Code)
+				}
+
+				assert.ElementsMatch(t, []string{"10710", "47240"}, codes)
+			}
+		})
+	}
+
+	assert.Empty(t, si.Find(testStopWords.Terms("unclassified")))
+}
+
+func TestGetIndustrySynonyms(t *testing.T) {
+	err := os.WriteFile("synonyms.csv", []byte("SIC2007,Activity\n 86230 ,Dentist\n86230,Dental surgeon\n"), 0o600)
+	if err != nil {
+		t.Fatalf("Failed to write test data: %v", err)
+	}
+	defer os.Remove("synonyms.csv")
+
+	synonyms, err := getIndustrySynonyms(&config.Config{IndustrySynonymFile: "synonyms.csv"})
+	assert.Nil(t, err)
+
+	assert.Equal(t, []IndustrySynonym{
+		{Code: "86230", Term: "Dentist"},
+		{Code: "86230", Term: "Dental surgeon"},
+	}, synonyms)
+}
@@ -0,0 +1,48 @@
+package db
+
+import (
+	"os"
+	"strings"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/config"
+	"github.com/gocarina/gocsv"
+)
+
+// TimeSeries is an ONS time series, identified by its four character CDID
+// such as ABMI, and the dataset it is published in
+type TimeSeries struct {
+	CDID    string `csv:"CDID"`
+	Title   string `csv:"Title"`
+	Dataset string `csv:"Dataset"`
+}
+
+// NewTimeSeriesIndex indexes series by their CDID
+func NewTimeSeriesIndex(series []TimeSeries) *Index[TimeSeries] {
+	return NewIndex(series, timeSeriesKey)
+}
+
+func timeSeriesKey(series TimeSeries) string {
+	return series.CDID
+}
+
+func getTimeSeries(cfg *config.Config) ([]TimeSeries, error) {
+	file, err := os.Open(cfg.TimeSeriesDataFile)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	series := []TimeSeries{}
+
+	if err := gocsv.UnmarshalFile(file, &series); err != nil {
+		return nil, err
+	}
+
+	for i := range series {
+		series[i].CDID = strings.ToUpper(strings.TrimSpace(series[i].CDID))
+		series[i].Dataset = strings.TrimSpace(series[i].Dataset)
+	}
+
+	return series, nil
+}
@@ -0,0 +1,30 @@
+package db
+
+import (
+	"os"
+	"testing"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTimeSeries(t *testing.T) {
+	err := os.WriteFile("timeseries.csv", []byte("CDID,Title,Dataset\n abmi ,Gross Domestic Product: chained volume measures,PN2\nD7G7,CPI INDEX 00: ALL ITEMS 2015=100, MM23\n"), 0o600)
+	if err != nil {
+		t.Fatalf("Failed to write test data: %v", err)
+	}
+	defer os.Remove("timeseries.csv")
+
+	series, err := getTimeSeries(&config.Config{TimeSeriesDataFile: "timeseries.csv"})
+	assert.Nil(t, err)
+
+	assert.Equal(t, []TimeSeries{
+		{CDID: "ABMI", Title: "Gross Domestic Product: chained volume measures", Dataset: "PN2"},
+		{CDID: "D7G7", Title: "CPI INDEX 00: ALL ITEMS 2015=100", Dataset: "MM23"},
+	}, series)
+
+	idx := NewTimeSeriesIndex(series)
+	assert.Len(t, idx.Get("D7G7"), 1)
+	assert.Empty(t, idx.Get("MGSX"))
+	assert.Empty(t, NewTimeSeriesIndex(nil).Get("ABMI"))
+}
@@ -0,0 +1,113 @@
+package db
+
+import (
+	"os"
+	"strings"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/config"
+	"github.com/ONSdigital/dp-search-scrubber-api/text"
+	"github.com/gocarina/gocsv"
+)
+
+// WelshName is the Welsh name of a local authority or region, given by its
+// GSS code
+type WelshName struct {
+	Code string `csv:"Code"`
+	Name string `csv:"Welsh Name"`
+}
+
+func getWelshNames(cfg *config.Config) ([]WelshName, error) {
+	file, err := os.Open(cfg.AreaWelshNameFile)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	names := []WelshName{}
+
+	if err := gocsv.UnmarshalFile(file, &names); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+func loadWelshNames(cfg *config.Config, areas []Area) error {
+	names, err := getWelshNames(cfg)
+	if err != nil {
+		return err
+	}
+
+	addWelshNames(areas, names)
+
+	return nil
+}
+
+// addWelshNames sets the Welsh names of the local authority and region of
+// each area that has one in names
+func addWelshNames(areas []Area, names []WelshName) {
+	byCode := make(map[string]string, len(names))
+	for _, n := range names {
+		if code, name := strings.TrimSpace(n.Code), strings.TrimSpace(n.Name); code != "" && name != "" {
+			byCode[code] = name
+		}
+	}
+
+	for i := range areas {
+		areas[i].LAWelshName = byCode[areas[i].LocalAuthorityCode]
+		areas[i].RegionWelshName = byCode[areas[i].RegionCode]
+	}
+}
+
+// getWelshIndustries reads the Welsh SIC descriptions, which have the same
+// columns as the English ones
+func getWelshIndustries(cfg *config.Config) ([]Industry, error) {
+	file, err := os.Open(cfg.IndustryWelshDataFile)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	ir := []Industry{}
+
+	if err := gocsv.UnmarshalFile(file, &ir); err != nil {
+		return nil, err
+	}
+
+	return ir, nil
+}
+
+func loadWelshIndustries(cfg *config.Config, industries []Industry) error {
+	welsh, err := getWelshIndustries(cfg)
+	if err != nil {
+		return err
+	}
+
+	addWelshIndustries(industries, welsh)
+
+	return nil
+}
+
+// addWelshIndustries sets the Welsh name of each industry found in welsh
+func addWelshIndustries(industries, welsh []Industry) {
+	byCode := make(map[string]string, len(welsh))
+	for _, industry := range welsh {
+		byCode[strings.TrimSpace(industry.Code)] = strings.TrimSpace(industry.Name)
+	}
+
+	for i := range industries {
+		industries[i].WelshName = byCode[industries[i].Code]
+	}
+}
+
+// localName returns welsh when it is set and the language is Welsh, or
+// otherwise the English name
+func localName(language, english, welsh string) string {
+	if language == text.Welsh && welsh != "" {
+		return welsh
+	}
+
+	return english
+}
@@ -0,0 +1,56 @@
+package db
+
+import (
+	"os"
+	"testing"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/config"
+	"github.com/ONSdigital/dp-search-scrubber-api/text"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadWelshNames(t *testing.T) {
+	err := os.WriteFile("welsh_names.csv", []byte("Code,Welsh Name\n W06000015 ,Caerdydd\nW92000004,Cymru\nE09000001,\n"), 0o600)
+	if err != nil {
+		t.Fatalf("Failed to write test data: %v", err)
+	}
+	defer os.Remove("welsh_names.csv")
+
+	areas := []Area{
+		{OutputAreaCode: "W00010000", LocalAuthorityCode: "W06000015", LAName: "Cardiff", RegionCode: "W92000004", RegionName: "Wales"},
+		{OutputAreaCode: "E00000001", LocalAuthorityCode: "E09000001", LAName: "City of London", RegionCode: "E12000007", RegionName: "London"},
+	}
+
+	assert.Nil(t, loadWelshNames(&config.Config{AreaWelshNameFile: "welsh_names.csv"}, areas))
+
+	assert.Equal(t, "Caerdydd", areas[0].LAWelshName)
+	assert.Equal(t, "Cymru", areas[0].RegionWelshName)
+	assert.Equal(t, "Caerdydd", areas[0].LANameIn(text.Welsh))
+	assert.Equal(t, "Cardiff", areas[0].LANameIn(text.English))
+
+	// names without a Welsh name are given in English
+	assert.Equal(t, "", areas[1].LAWelshName)
+	assert.Equal(t, "City of London", areas[1].LANameIn(text.Welsh))
+	assert.Equal(t, "London", areas[1].RegionNameIn(text.Welsh))
+}
+
+func TestLoadWelshIndustries(t *testing.T) {
+	err := os.WriteFile("industries_cy.csv", []byte("SIC Code,Description\n01120,Tyfu reis\n"), 0o600)
+	if err != nil {
+		t.Fatalf("Failed to write test data: %v", err)
+	}
+	defer os.Remove("industries_cy.csv")
+
+	industries := []Industry{
+		{Code: "01120", Name: "Growing of rice"},
+		{Code: "01130", Name: "Growing of vegetables and melons, roots and tubers"},
+	}
+
+	assert.Nil(t, loadWelshIndustries(&config.Config{IndustryWelshDataFile: "industries_cy.csv"}, industries))
+
+	assert.Equal(t, "Tyfu reis", industries[0].NameIn(text.Welsh))
+	assert.Equal(t, "Growing of rice", industries[0].NameIn(text.English))
+	assert.Equal(t, "Growing of vegetables and melons, roots and tubers", industries[1].NameIn(text.Welsh))
+
+	assert.NotNil(t, loadWelshIndustries(&config.Config{IndustryWelshDataFile: "missing.csv"}, industries))
+}
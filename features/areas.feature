@@ -0,0 +1,20 @@
+Feature: Areas Endpoint
+    Scenario: When Searching for areas within a bounding box I get a successful response
+        When I GET "/areas/within?bbox=-0.1,51.5,-0.09,51.52"
+        Then the HTTP status code should be "200"
+
+    Scenario: When Searching for areas within a radius I get a successful response
+        When I GET "/areas/within?lat=51.51&lon=-0.09&radius=500&group_by=none"
+        Then the HTTP status code should be "200"
+
+    Scenario: When Searching for areas without a bounding box or radius I get a bad request
+        When I GET "/areas/within"
+        Then the HTTP status code should be "400"
+
+    Scenario: When locating a point I get a successful response
+        When I GET "/areas/locate?lat=51.5105&lon=-0.0975"
+        Then the HTTP status code should be "200"
+
+    Scenario: When locating without a point I get a bad request
+        When I GET "/areas/locate?lat=51.5105"
+        Then the HTTP status code should be "400"
@@ -17,7 +17,7 @@ Feature: Scrubber Endpoint
 
     Scenario: When Searching for With both OAC and SIC that have special characters between then I get resp as in json
         When I GET "/scrubber?q=01230,E00000001"
-        And the response body is the same as the json in "./features/testdata/expecteddata/fullResponse.json"
+        And the response body is the same as the json in "./features/testdata/expecteddata/fullResponseWithSpecialCharacters.json"
 
     Scenario: When Searching for With both OAC and SIC that have special characters and multiple OAC then I get resp as in json
         When I GET "/scrubber?q=01230,E00000001,E00000014,E00000017,E00000016"
@@ -38,3 +38,86 @@ Feature: Scrubber Endpoint
     Scenario: When Searching for With both OAC and SIC where SIC is has a typo but is correct length then I get resp as in json
         When I GET "/scrubber?q=01230,01240,01251,E00000014"
         And the response body is the same as the json in "./features/testdata/expecteddata/fullResponseIfSICHasATypo.json"
+
+    Scenario: When Searching for With only an LSOA code I get resp as in json
+        When I GET "/scrubber?q=E01000001"
+        And the response body is the same as the json in "./features/testdata/expecteddata/onlyLSOAResponse.json"
+
+    Scenario: When Searching for multiple OAC grouped by supergroup I get resp as in json
+        When I GET "/scrubber?q=E00000001,E00000014&group_by=supergroup"
+        And the response body is the same as the json in "./features/testdata/expecteddata/groupedBySupergroupResponse.json"
+
+    Scenario: When Searching for coordinates I get the nearest output area as in json
+        When I GET "/scrubber?q=dentists%2051.5101,-0.0979"
+        Then the HTTP status code should be "200"
+        And the response body is the same as the json in "./features/testdata/expecteddata/nearestOAResponse.json"
+
+    Scenario: When Searching for Scottish and Northern Irish areas I get resp as in json
+        When I GET "/scrubber?q=S00088956%20N00000001"
+        Then the HTTP status code should be "200"
+        And the response body is the same as the json in "./features/testdata/expecteddata/scotlandAndNorthernIrelandResponse.json"
+
+    Scenario: When Searching for industry synonyms I get resp as in json
+        When I GET "/scrubber?q=vineyard%20and%20tea%20plantation"
+        Then the HTTP status code should be "200"
+        And the response body is the same as the json in "./features/testdata/expecteddata/synonymResponse.json"
+
+    Scenario: When Searching in Welsh I get resp as in json
+        When I GET "/scrubber?q=tyfu%20reis%20yng%20Nghaerdydd&lang=cy"
+        Then the HTTP status code should be "200"
+        And the response body is the same as the json in "./features/testdata/expecteddata/welshResponse.json"
+
+    Scenario: When Searching with a quoted phrase and an exclusion I get resp as in json
+        When I GET "/scrubber?q=%22growing%20of%20rice%22%20E00000001%20E00000003%20-E00000003%20-wholesale"
+        Then the HTTP status code should be "200"
+        And the response body is the same as the json in "./features/testdata/expecteddata/phraseResponse.json"
+
+    Scenario: When Searching for an output area and excluding it I get no country
+        When I GET "/scrubber?q=E00000001%20-E00000001"
+        Then the HTTP status code should be "200"
+        And the response body is the same as the json in "./features/testdata/expecteddata/excludedAreaResponse.json"
+
+    Scenario: When Searching with periods of time I get resp as in json
+        When I GET "/scrubber?q=dentists%2001230%20Q3%202022%20to%20March%202023"
+        Then the HTTP status code should be "200"
+        And the response body is the same as the json in "./features/testdata/expecteddata/periodResponse.json"
+
+    Scenario: When Searching with time series CDIDs I get resp as in json
+        When I GET "/scrubber?q=gdp%20ABMI%20d7g7%20mgsx"
+        Then the HTTP status code should be "200"
+        And the response body is the same as the json in "./features/testdata/expecteddata/timeSeriesResponse.json"
+
+    Scenario: When Searching with Census table and variable codes I get resp as in json
+        When I GET "/scrubber?q=ts017%20RM052%20hh_size%20by%20sex"
+        Then the HTTP status code should be "200"
+        And the response body is the same as the json in "./features/testdata/expecteddata/censusResponse.json"
+
+    Scenario: When Searching with SOC codes and occupation titles I get resp as in json
+        When I GET "/scrubber?q=2253%20vacancies%20for%20bakers%20and%20flour%20confectioners%202021"
+        Then the HTTP status code should be "200"
+        And the response body is the same as the json in "./features/testdata/expecteddata/occupationResponse.json"
+
+    Scenario: When Searching with SIC 2003 codes and crosswalks I get resp as in json
+        When I GET "/scrubber?q=01121%20SIC%202003%200113%20-01280&crosswalk=nace,isic"
+        Then the HTTP status code should be "200"
+        And the response body is the same as the json in "./features/testdata/expecteddata/crosswalkResponse.json"
+
+    Scenario: When Searching with retired geography codes I get resp as in json
+        When I GET "/scrubber?q=dentists%20w05000015%20E00000002"
+        Then the HTTP status code should be "200"
+        And the response body is the same as the json in "./features/testdata/expecteddata/retiredCodeResponse.json"
+
+    Scenario: When Searching with town and city names I get resp as in json
+        When I GET "/scrubber?q=dentists%20in%20Barbican%20or%20Newport%20Cardiff&ambiguity=all"
+        Then the HTTP status code should be "200"
+        And the response body is the same as the json in "./features/testdata/expecteddata/placeResponse.json"
+
+    Scenario: When Searching with ambiguous codes and names I get resp as in json
+        When I GET "/scrubber?q=dentists%20in%20Newport%2001121"
+        Then the HTTP status code should be "200"
+        And the response body is the same as the json in "./features/testdata/expecteddata/ambiguityResponse.json"
+
+    Scenario: When Searching with area and industry codes I get the query rewritten with their names as in json
+        When I GET "/scrubber?q=dentists%20in%20E00000014%2001140"
+        Then the HTTP status code should be "200"
+        And the response body is the same as the json in "./features/testdata/expecteddata/rewrittenQueryResponse.json"
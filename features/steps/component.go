@@ -41,7 +41,21 @@ func NewComponent() (*Component, error) {
 	}
 
 	c.Config.AreaDataFile = "features/testdata/areas.csv"
+	c.Config.AreaLookupFile = "features/testdata/lookup.csv"
+	c.Config.AreaWelshNameFile = "features/testdata/welsh_names.csv"
+	c.Config.BoundaryDataFile = "features/testdata/boundaries.geojson"
+	c.Config.CensusDataFile = "features/testdata/census.csv"
+	c.Config.CentroidDataFile = "features/testdata/centroids.csv"
+	c.Config.CodeHistoryFile = "features/testdata/code_history.csv"
+	c.Config.IndustryConcordanceFile = "features/testdata/concordance.csv"
 	c.Config.IndustryDataFile = "features/testdata/industries.csv"
+	c.Config.IndustrySynonymFile = "features/testdata/synonyms.csv"
+	c.Config.IndustryWelshDataFile = "features/testdata/industries_cy.csv"
+	c.Config.NorthernIrelandAreaDataFile = "features/testdata/northern_ireland.csv"
+	c.Config.OccupationDataFile = "features/testdata/soc2020.csv"
+	c.Config.PlaceDataFile = "features/testdata/places.csv"
+	c.Config.ScotlandAreaDataFile = "features/testdata/scotland.csv"
+	c.Config.TimeSeriesDataFile = "features/testdata/timeseries.csv"
 
 	initMock := &mock.InitialiserMock{
 		DoGetHealthCheckFunc: c.DoGetHealthcheckOk,
@@ -0,0 +1,124 @@
+// Package geo converts between the coordinate systems used by the area data
+// and the queries.
+package geo
+
+import "math"
+
+// ellipsoid is the shape of the earth assumed by a datum
+type ellipsoid struct {
+	a, b float64
+}
+
+var (
+	airy1830 = ellipsoid{a: 6377563.396, b: 6356256.909}
+	grs80    = ellipsoid{a: 6378137, b: 6356752.3141}
+)
+
+// the transverse Mercator projection of the British National Grid
+const (
+	bngScale     = 0.9996012717
+	bngLatOrigin = 49 * math.Pi / 180
+	bngLonOrigin = -2 * math.Pi / 180
+	bngEasting   = 400000
+	bngNorthing  = -100000
+)
+
+// OSGBToWGS84 converts an easting and northing on the British National Grid
+// to the WGS84 latitude and longitude in degrees used by GPS and the area
+// centroids, which is accurate to around 5 metres
+func OSGBToWGS84(easting, northing float64) (lat, lon float64) {
+	lat, lon = inverseTransverseMercator(easting, northing)
+
+	x, y, z := toCartesian(lat, lon, airy1830)
+	x, y, z = helmertOSGB36ToWGS84(x, y, z)
+	lat, lon = fromCartesian(x, y, z, grs80)
+
+	return lat * 180 / math.Pi, lon * 180 / math.Pi
+}
+
+// inverseTransverseMercator returns the OSGB36 latitude and longitude in
+// radians of an easting and northing, following the Ordnance Survey's "A
+// guide to coordinate systems in Great Britain"
+func inverseTransverseMercator(easting, northing float64) (lat, lon float64) {
+	a, b := airy1830.a, airy1830.b
+	e2 := 1 - b*b/(a*a)
+	n := (a - b) / (a + b)
+	n2, n3 := n*n, n*n*n
+
+	meridionalArc := func(lat float64) float64 {
+		dLat, sLat := lat-bngLatOrigin, lat+bngLatOrigin
+
+		return b * bngScale * ((1+n+5.0/4*n2+5.0/4*n3)*dLat -
+			(3*n+3*n2+21.0/8*n3)*math.Sin(dLat)*math.Cos(sLat) +
+			(15.0/8*n2+15.0/8*n3)*math.Sin(2*dLat)*math.Cos(2*sLat) -
+			35.0/24*n3*math.Sin(3*dLat)*math.Cos(3*sLat))
+	}
+
+	lat = bngLatOrigin
+	m := 0.0
+
+	for ok := true; ok; ok = math.Abs(northing-bngNorthing-m) >= 0.00001 {
+		lat = (northing-bngNorthing-m)/(a*bngScale) + lat
+		m = meridionalArc(lat)
+	}
+
+	sinLat, cosLat, tanLat := math.Sin(lat), math.Cos(lat), math.Tan(lat)
+
+	nu := a * bngScale / math.Sqrt(1-e2*sinLat*sinLat)
+	rho := a * bngScale * (1 - e2) / math.Pow(1-e2*sinLat*sinLat, 1.5)
+	eta2 := nu/rho - 1
+
+	tan2, tan4, tan6 := tanLat*tanLat, math.Pow(tanLat, 4), math.Pow(tanLat, 6)
+	secLat := 1 / cosLat
+
+	vii := tanLat / (2 * rho * nu)
+	viii := tanLat / (24 * rho * math.Pow(nu, 3)) * (5 + 3*tan2 + eta2 - 9*tan2*eta2)
+	ix := tanLat / (720 * rho * math.Pow(nu, 5)) * (61 + 90*tan2 + 45*tan4)
+	x := secLat / nu
+	xi := secLat / (6 * math.Pow(nu, 3)) * (nu/rho + 2*tan2)
+	xii := secLat / (120 * math.Pow(nu, 5)) * (5 + 28*tan2 + 24*tan4)
+	xiia := secLat / (5040 * math.Pow(nu, 7)) * (61 + 662*tan2 + 1320*tan4 + 720*tan6)
+
+	dE := easting - bngEasting
+
+	lat = lat - vii*dE*dE + viii*math.Pow(dE, 4) - ix*math.Pow(dE, 6)
+	lon = bngLonOrigin + x*dE - xi*math.Pow(dE, 3) + xii*math.Pow(dE, 5) - xiia*math.Pow(dE, 7)
+
+	return lat, lon
+}
+
+func toCartesian(lat, lon float64, el ellipsoid) (x, y, z float64) {
+	e2 := 1 - el.b*el.b/(el.a*el.a)
+	nu := el.a / math.Sqrt(1-e2*math.Sin(lat)*math.Sin(lat))
+
+	return nu * math.Cos(lat) * math.Cos(lon), nu * math.Cos(lat) * math.Sin(lon), (1 - e2) * nu * math.Sin(lat)
+}
+
+func fromCartesian(x, y, z float64, el ellipsoid) (lat, lon float64) {
+	e2 := 1 - el.b*el.b/(el.a*el.a)
+	p := math.Sqrt(x*x + y*y)
+
+	lat = math.Atan2(z, p*(1-e2))
+
+	for i := 0; i < 10; i++ {
+		nu := el.a / math.Sqrt(1-e2*math.Sin(lat)*math.Sin(lat))
+		lat = math.Atan2(z+e2*nu*math.Sin(lat), p)
+	}
+
+	return lat, math.Atan2(y, x)
+}
+
+// helmertOSGB36ToWGS84 shifts cartesian coordinates from the OSGB36 datum to
+// WGS84
+func helmertOSGB36ToWGS84(x, y, z float64) (float64, float64, float64) {
+	const (
+		tx, ty, tz = 446.448, -125.157, 542.060
+		scale      = -20.4894e-6
+		arcSecond  = math.Pi / (180 * 3600)
+		rx, ry, rz = 0.1502 * arcSecond, 0.2470 * arcSecond, 0.8421 * arcSecond
+	)
+
+	return tx + (1+scale)*x - rz*y + ry*z,
+		ty + rz*x + (1+scale)*y - rx*z,
+		tz - ry*x + rx*y + (1+scale)*z
+}
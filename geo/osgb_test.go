@@ -0,0 +1,16 @@
+package geo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOSGBToWGS84(t *testing.T) {
+	// the worked example in the Ordnance Survey's guide to coordinate
+	// systems, whose WGS84 position is 52°39'28.72"N 1°42'57.79"E
+	lat, lon := OSGBToWGS84(651409.903, 313177.270)
+
+	assert.InDelta(t, 52.657978, lat, 0.0001)
+	assert.InDelta(t, 1.716053, lon, 0.0001)
+}
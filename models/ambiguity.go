@@ -0,0 +1,141 @@
+package models
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// Values of the ambiguity parameter, setting which of the candidates for an
+// ambiguous token or name go into the results: the one with the highest
+// score, every one, or none of them
+const (
+	AmbiguityFirst = "first"
+	AmbiguityAll   = "all"
+	AmbiguityNone  = "none"
+)
+
+var validAmbiguities = []string{AmbiguityFirst, AmbiguityAll, AmbiguityNone}
+
+// ValidAmbiguity reports whether policy is one of the ambiguity values
+func ValidAmbiguity(policy string) bool {
+	return slices.Contains(validAmbiguities, policy)
+}
+
+// Scorer is a Matcher that says how likely a token it matches is to be one of
+// its codes, from 0 to 1, for choosing between matchers when more than one
+// matches a token. Matchers that are not Scorers score defaultScore.
+type Scorer interface {
+	Score(token string) float64
+}
+
+const defaultScore = 0.5
+
+// Validator is a Matcher that matches tokens by their shape alone, and so
+// may match codes that do not exist, which it reports whether are valid. An
+// invalid code is only a candidate when no other matcher finds a valid one.
+type Validator interface {
+	Valid(code string) bool
+}
+
+// matchCandidates returns the codes that each of matchers finds token to be,
+// best first, scored as ScoreCandidates does. Codes that are not valid are
+// left out when any are.
+func matchCandidates(matchers []Matcher, token string) []CandidateResp {
+	var (
+		candidates []CandidateResp
+		weights    []float64
+		invalid    []bool
+		anyValid   bool
+	)
+
+	for _, matcher := range matchers {
+		code, ok := matcher.Match(token)
+		if !ok {
+			continue
+		}
+
+		weight := defaultScore
+		if scorer, ok := matcher.(Scorer); ok {
+			weight = scorer.Score(token)
+		}
+
+		validator, ok := matcher.(Validator)
+		isInvalid := ok && !validator.Valid(code)
+		anyValid = anyValid || !isInvalid
+
+		candidates = append(candidates, CandidateResp{Kind: matcher.Name(), Code: code})
+		weights = append(weights, weight)
+		invalid = append(invalid, isInvalid)
+	}
+
+	if anyValid {
+		n := 0
+
+		for i := range candidates {
+			if !invalid[i] {
+				candidates[n], weights[n] = candidates[i], weights[i]
+				n++
+			}
+		}
+
+		candidates, weights = candidates[:n], weights[:n]
+	}
+
+	ScoreCandidates(candidates, weights)
+
+	return candidates
+}
+
+// shareOf returns weight as a share of total, to two decimal places, sharing
+// equally between n candidates when none has any weight
+func shareOf(weight, total float64, n int) float64 {
+	if total == 0 {
+		return math.Round(100/float64(n)) / 100
+	}
+
+	return math.Round(weight/total*100) / 100
+}
+
+// ScoreCandidates sets the scores of candidates to their shares of weights,
+// and sorts them best first, keeping the order of those with the same score
+func ScoreCandidates(candidates []CandidateResp, weights []float64) {
+	total := 0.0
+	for _, weight := range weights {
+		total += weight
+	}
+
+	for i := range candidates {
+		candidates[i].Score = shareOf(weights[i], total, len(candidates))
+	}
+
+	slices.SortStableFunc(candidates, func(a, b CandidateResp) int { return cmp.Compare(b.Score, a.Score) })
+}
+
+// SelectCandidates marks which of candidates, sorted best first, go into the
+// results under policy
+func SelectCandidates(candidates []CandidateResp, policy string) {
+	for i := range candidates {
+		candidates[i].Selected = policy == AmbiguityAll || policy == AmbiguityFirst && i == 0
+	}
+}
+
+// ResolveAmbiguities chooses the codes of the ambiguous tokens of the query
+// that are kept, under the ambiguity parameter of the query or, when it was
+// not given, defaultPolicy
+func (sp *ScrubberParams) ResolveAmbiguities(defaultPolicy string) {
+	policy := sp.Ambiguity
+	if policy == "" {
+		policy = defaultPolicy
+	}
+
+	for i := range sp.Ambiguities {
+		SelectCandidates(sp.Ambiguities[i].Candidates, policy)
+
+		for _, candidate := range sp.Ambiguities[i].Candidates {
+			if candidate.Selected {
+				sp.Codes = addCode(sp.Codes, candidate.Kind, candidate.Code)
+			}
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package models
+
+import (
+	"net/url"
+	"slices"
+	"testing"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/text"
+	"github.com/stretchr/testify/assert"
+)
+
+// anyMatcher matches every five digit token, as SIC codes look
+type anyMatcher struct {
+	name  string
+	score float64
+}
+
+func (m anyMatcher) Name() string {
+	return m.name
+}
+
+func (anyMatcher) Match(token string) (string, bool) {
+	return token, sicCodeRe.MatchString(token)
+}
+
+func (m anyMatcher) Score(string) float64 {
+	return m.score
+}
+
+func TestAmbiguousTokens(t *testing.T) {
+	matchers := []Matcher{SICMatcher{}, anyMatcher{name: "likely", score: 1}, anyMatcher{name: "unlikely", score: 0}}
+
+	tests := []struct {
+		policy   string
+		expected map[string][]string
+		selected []bool
+	}{
+		{policy: AmbiguityFirst, expected: map[string][]string{"likely": {"12345"}}, selected: []bool{true, false, false}},
+		{policy: AmbiguityAll, expected: map[string][]string{"likely": {"12345"}, CodeSIC: {"12345"}, "unlikely": {"12345"}}, selected: []bool{true, true, true}},
+		{policy: AmbiguityNone, selected: []bool{false, false, false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			params, err := GetScrubberParams(url.Values{"q": {"12345 dentists"}, "ambiguity": {tt.policy}}, "", text.DefaultStopWords(), matchers)
+			assert.Nil(t, err)
+
+			assert.Equal(t, "dentists", params.Query)
+			assert.Empty(t, params.Codes)
+
+			if assert.Len(t, params.Ambiguities, 1) {
+				assert.Equal(t, "12345", params.Ambiguities[0].Term)
+				assert.Equal(t, []CandidateResp{
+					{Kind: "likely", Code: "12345", Score: 0.67},
+					{Kind: CodeSIC, Code: "12345", Score: 0.33},
+					{Kind: "unlikely", Code: "12345", Score: 0},
+				}, params.Ambiguities[0].Candidates)
+			}
+
+			// the policy of the query is used over the default
+			params.ResolveAmbiguities(AmbiguityNone)
+			assert.Equal(t, tt.expected, params.Codes)
+
+			for i, candidate := range params.Ambiguities[0].Candidates {
+				assert.Equal(t, tt.selected[i], candidate.Selected)
+			}
+		})
+	}
+}
+
+// validMatcher is an anyMatcher for which only the codes in valid exist
+type validMatcher struct {
+	anyMatcher
+	valid []string
+}
+
+func (m validMatcher) Valid(code string) bool {
+	return slices.Contains(m.valid, code)
+}
+
+func TestInvalidCandidates(t *testing.T) {
+	matchers := []Matcher{validMatcher{anyMatcher: anyMatcher{name: CodeSIC, score: 1}, valid: []string{"11111"}}, anyMatcher{name: "other", score: 0.5}}
+
+	candidates := matchCandidates(matchers, "12345")
+	assert.Equal(t, []CandidateResp{{Kind: "other", Code: "12345", Score: 1}}, candidates)
+
+	candidates = matchCandidates(matchers, "11111")
+	assert.Equal(t, []CandidateResp{{Kind: CodeSIC, Code: "11111", Score: 0.67}, {Kind: "other", Code: "11111", Score: 0.33}}, candidates)
+
+	// an invalid code is still a candidate when it is the only one
+	candidates = matchCandidates(matchers[:1], "12345")
+	assert.Equal(t, []CandidateResp{{Kind: CodeSIC, Code: "12345", Score: 1}}, candidates)
+}
+
+func TestExcludedAmbiguousToken(t *testing.T) {
+	matchers := []Matcher{SICMatcher{}, anyMatcher{name: "likely", score: 1}}
+
+	params, err := GetScrubberParams(url.Values{"q": {"dentists -12345"}}, "", text.DefaultStopWords(), matchers)
+	assert.Nil(t, err)
+
+	assert.Equal(t, map[string][]string{"likely": {"12345"}}, params.ExcludedCodes)
+}
+
+func TestScoreCandidates(t *testing.T) {
+	candidates := []CandidateResp{{Code: "a"}, {Code: "b"}, {Code: "c"}}
+
+	ScoreCandidates(candidates, []float64{0, 0, 0})
+	assert.Equal(t, []CandidateResp{{Code: "a", Score: 0.33}, {Code: "b", Score: 0.33}, {Code: "c", Score: 0.33}}, candidates)
+
+	ScoreCandidates(candidates, []float64{1, 2, 1})
+	assert.Equal(t, []CandidateResp{{Code: "b", Score: 0.5}, {Code: "a", Score: 0.25}, {Code: "c", Score: 0.25}}, candidates)
+}
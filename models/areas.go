@@ -0,0 +1,209 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// AreasWithinParams are the parameters of a search for the output areas
+// within a bounding box, or within a radius of a centre point
+type AreasWithinParams struct {
+	BBox    *BBox
+	Centre  *Coordinate
+	Radius  float64
+	GroupBy string
+}
+
+// BBox is a box given by its south west and north east corners
+type BBox struct {
+	Min Coordinate
+	Max Coordinate
+}
+
+// kmPerDegree is the length of a degree of latitude, or of longitude at the
+// equator, in kilometres
+const kmPerDegree = 111.195
+
+// Area returns roughly how large the box is in square kilometres, which is
+// close enough over the size of Great Britain
+func (b BBox) Area() float64 {
+	midLatitude := (b.Min.Latitude + b.Max.Latitude) / 2 * math.Pi / 180
+
+	height := (b.Max.Latitude - b.Min.Latitude) * kmPerDegree
+	width := (b.Max.Longitude - b.Min.Longitude) * kmPerDegree * math.Cos(midLatitude)
+
+	return height * width
+}
+
+// AreasWithinLimits bound how large a search for the areas within a box or
+// radius can be, as the largest could return every output area. A limit of
+// zero is no limit.
+type AreasWithinLimits struct {
+	// MaxBBoxArea is the largest bounding box in square kilometres
+	MaxBBoxArea float64
+	// MaxRadius is the largest radius in metres
+	MaxRadius float64
+}
+
+// GetAreasWithinParams reads either a bbox=minLon,minLat,maxLon,maxLat
+// parameter, or lat, lon and radius (in metres) parameters, no larger than
+// limits
+func GetAreasWithinParams(query url.Values, limits AreasWithinLimits) (*AreasWithinParams, error) {
+	result := AreasWithinParams{}
+
+	for name, values := range query {
+		if len(values) > 1 {
+			return nil, fmt.Errorf("one %s expected, found multiple", name)
+		}
+	}
+
+	if query.Has("group_by") {
+		result.GroupBy = query.Get("group_by")
+
+		if !slices.Contains(validGroupBy, result.GroupBy) {
+			return nil, fmt.Errorf("invalid group_by %q, expected one of %s", result.GroupBy, strings.Join(validGroupBy, ", "))
+		}
+	}
+
+	hasRadius := query.Has("lat") || query.Has("lon") || query.Has("radius")
+
+	switch {
+	case query.Has("bbox") && hasRadius:
+		return nil, fmt.Errorf("either bbox or lat, lon and radius expected, found both")
+	case query.Has("bbox"):
+		bbox, err := parseBBox(query.Get("bbox"))
+		if err != nil {
+			return nil, err
+		}
+
+		if limits.MaxBBoxArea > 0 && bbox.Area() > limits.MaxBBoxArea {
+			return nil, fmt.Errorf("bbox is larger than the maximum of %g square kilometres", limits.MaxBBoxArea)
+		}
+
+		result.BBox = bbox
+	case hasRadius:
+		centre, radius, err := parseRadius(query)
+		if err != nil {
+			return nil, err
+		}
+
+		if limits.MaxRadius > 0 && radius > limits.MaxRadius {
+			return nil, fmt.Errorf("radius is larger than the maximum of %g metres", limits.MaxRadius)
+		}
+
+		result.Centre, result.Radius = centre, radius
+	default:
+		return nil, fmt.Errorf("no bbox or lat, lon and radius provided")
+	}
+
+	return &result, nil
+}
+
+func parseBBox(value string) (*BBox, error) {
+	parts := strings.Split(value, ",")
+	if len(parts) != 4 {
+		return nil, fmt.Errorf("bbox expected as minLon,minLat,maxLon,maxLat")
+	}
+
+	var values [4]float64
+
+	for i, part := range parts {
+		v, err := parseFinite(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("invalid bbox value %q", part)
+		}
+
+		values[i] = v
+	}
+
+	bbox := BBox{
+		Min: Coordinate{Latitude: values[1], Longitude: values[0]},
+		Max: Coordinate{Latitude: values[3], Longitude: values[2]},
+	}
+
+	if !bbox.Min.Valid() || !bbox.Max.Valid() {
+		return nil, fmt.Errorf("bbox is outside of the range of latitude and longitude")
+	}
+
+	if bbox.Min.Latitude > bbox.Max.Latitude || bbox.Min.Longitude > bbox.Max.Longitude {
+		return nil, fmt.Errorf("bbox minimum is greater than its maximum")
+	}
+
+	return &bbox, nil
+}
+
+func parseRadius(query url.Values) (*Coordinate, float64, error) {
+	var values [3]float64
+
+	for i, name := range []string{"lat", "lon", "radius"} {
+		if !query.Has(name) {
+			return nil, 0, fmt.Errorf("no %s provided", name)
+		}
+
+		v, err := parseFinite(query.Get(name))
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid %s %q", name, query.Get(name))
+		}
+
+		values[i] = v
+	}
+
+	centre := Coordinate{Latitude: values[0], Longitude: values[1]}
+	if !centre.Valid() {
+		return nil, 0, fmt.Errorf("lat and lon are outside of the range of latitude and longitude")
+	}
+
+	if values[2] <= 0 {
+		return nil, 0, fmt.Errorf("radius must be greater than zero")
+	}
+
+	return &centre, values[2], nil
+}
+
+// GetLocateParams reads the lat and lon parameters of a point to locate
+func GetLocateParams(query url.Values) (*Coordinate, error) {
+	var values [2]float64
+
+	for i, name := range []string{"lat", "lon"} {
+		if len(query[name]) > 1 {
+			return nil, fmt.Errorf("one %s expected, found multiple", name)
+		}
+
+		if !query.Has(name) {
+			return nil, fmt.Errorf("no %s provided", name)
+		}
+
+		v, err := parseFinite(query.Get(name))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q", name, query.Get(name))
+		}
+
+		values[i] = v
+	}
+
+	point := Coordinate{Latitude: values[0], Longitude: values[1]}
+	if !point.Valid() {
+		return nil, fmt.Errorf("lat and lon are outside of the range of latitude and longitude")
+	}
+
+	return &point, nil
+}
+
+// parseFinite parses a number, refusing NaN and infinities, which ParseFloat
+// accepts but which no coordinate or radius can be
+func parseFinite(value string) (float64, error) {
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, strconv.ErrSyntax
+	}
+
+	return v, nil
+}
@@ -0,0 +1,182 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAreasWithinParams(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    url.Values
+		expected *AreasWithinParams
+	}{
+		{
+			name: "bounding box",
+			query: url.Values{
+				"bbox": []string{"-0.12,51.5,-0.08,51.52"},
+			},
+			expected: &AreasWithinParams{
+				BBox: &BBox{
+					Min: Coordinate{Latitude: 51.5, Longitude: -0.12},
+					Max: Coordinate{Latitude: 51.52, Longitude: -0.08},
+				},
+			},
+		},
+		{
+			name: "radius grouped by region",
+			query: url.Values{
+				"lat":      []string{"51.51"},
+				"lon":      []string{"-0.09"},
+				"radius":   []string{"500"},
+				"group_by": []string{"region"},
+			},
+			expected: &AreasWithinParams{
+				Centre:  &Coordinate{Latitude: 51.51, Longitude: -0.09},
+				Radius:  500,
+				GroupBy: GroupByRegion,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := GetAreasWithinParams(tt.query, AreasWithinLimits{})
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, params)
+		})
+	}
+}
+
+func TestGetAreasWithinParamsReturnsError(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    url.Values
+		expected error
+	}{
+		{
+			name:     "no parameters",
+			query:    url.Values{},
+			expected: fmt.Errorf("no bbox or lat, lon and radius provided"),
+		},
+		{
+			name:     "bounding box and radius",
+			query:    url.Values{"bbox": []string{"-0.12,51.5,-0.08,51.52"}, "radius": []string{"500"}},
+			expected: fmt.Errorf("either bbox or lat, lon and radius expected, found both"),
+		},
+		{
+			name:     "bounding box with too few values",
+			query:    url.Values{"bbox": []string{"-0.12,51.5,-0.08"}},
+			expected: fmt.Errorf("bbox expected as minLon,minLat,maxLon,maxLat"),
+		},
+		{
+			name:     "bounding box with a value that is not a number",
+			query:    url.Values{"bbox": []string{"-0.12,north,-0.08,51.52"}},
+			expected: fmt.Errorf("invalid bbox value \"north\""),
+		},
+		{
+			name:     "bounding box the wrong way round",
+			query:    url.Values{"bbox": []string{"-0.08,51.52,-0.12,51.5"}},
+			expected: fmt.Errorf("bbox minimum is greater than its maximum"),
+		},
+		{
+			name:     "radius without a centre",
+			query:    url.Values{"lat": []string{"51.51"}, "radius": []string{"500"}},
+			expected: fmt.Errorf("no lon provided"),
+		},
+		{
+			name:     "negative radius",
+			query:    url.Values{"lat": []string{"51.51"}, "lon": []string{"-0.09"}, "radius": []string{"-5"}},
+			expected: fmt.Errorf("radius must be greater than zero"),
+		},
+		{
+			name:     "bounding box with a value of NaN",
+			query:    url.Values{"bbox": []string{"-0.12,NaN,-0.08,51.52"}},
+			expected: fmt.Errorf("invalid bbox value \"NaN\""),
+		},
+		{
+			name:     "bounding box with an infinite value",
+			query:    url.Values{"bbox": []string{"-Inf,51.5,-0.08,51.52"}},
+			expected: fmt.Errorf("invalid bbox value \"-Inf\""),
+		},
+		{
+			name:     "radius that is not a number",
+			query:    url.Values{"lat": []string{"51.51"}, "lon": []string{"-0.09"}, "radius": []string{"NaN"}},
+			expected: fmt.Errorf("invalid radius \"NaN\""),
+		},
+		{
+			name:     "infinite radius",
+			query:    url.Values{"lat": []string{"51.51"}, "lon": []string{"-0.09"}, "radius": []string{"+Inf"}},
+			expected: fmt.Errorf("invalid radius \"+Inf\""),
+		},
+		{
+			name:     "centre that is not a number",
+			query:    url.Values{"lat": []string{"nan"}, "lon": []string{"-0.09"}, "radius": []string{"5"}},
+			expected: fmt.Errorf("invalid lat \"nan\""),
+		},
+		{
+			name:     "centre out of range",
+			query:    url.Values{"lat": []string{"151.51"}, "lon": []string{"-0.09"}, "radius": []string{"5"}},
+			expected: fmt.Errorf("lat and lon are outside of the range of latitude and longitude"),
+		},
+		{
+			name:     "multiple values",
+			query:    url.Values{"bbox": []string{"-0.12,51.5,-0.08,51.52", "-0.12,51.5,-0.08,51.52"}},
+			expected: fmt.Errorf("one bbox expected, found multiple"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := GetAreasWithinParams(tt.query, AreasWithinLimits{})
+			assert.Nil(t, params)
+			assert.Equal(t, tt.expected, err)
+		})
+	}
+}
+
+func TestGetAreasWithinParamsLimits(t *testing.T) {
+	limits := AreasWithinLimits{MaxBBoxArea: 10, MaxRadius: 1000}
+
+	// about 2.2km by 2.8km
+	params, err := GetAreasWithinParams(url.Values{"bbox": []string{"-0.12,51.5,-0.08,51.52"}}, limits)
+	assert.Nil(t, err)
+	assert.InDelta(t, 6.16, params.BBox.Area(), 0.01)
+
+	params, err = GetAreasWithinParams(url.Values{"bbox": []string{"-0.2,51.4,0,51.6"}}, limits)
+	assert.Nil(t, params)
+	assert.Equal(t, fmt.Errorf("bbox is larger than the maximum of 10 square kilometres"), err)
+
+	params, err = GetAreasWithinParams(url.Values{"lat": []string{"51.51"}, "lon": []string{"-0.09"}, "radius": []string{"1000"}}, limits)
+	assert.Nil(t, err)
+	assert.Equal(t, 1000.0, params.Radius)
+
+	params, err = GetAreasWithinParams(url.Values{"lat": []string{"51.51"}, "lon": []string{"-0.09"}, "radius": []string{"1001"}}, limits)
+	assert.Nil(t, params)
+	assert.Equal(t, fmt.Errorf("radius is larger than the maximum of 1000 metres"), err)
+}
+
+func TestGetLocateParams(t *testing.T) {
+	point, err := GetLocateParams(url.Values{"lat": []string{"51.51"}, "lon": []string{"-0.09"}})
+	assert.Nil(t, err)
+	assert.Equal(t, &Coordinate{Latitude: 51.51, Longitude: -0.09}, point)
+
+	errorCases := []url.Values{
+		{},
+		{"lat": []string{"51.51"}},
+		{"lat": []string{"north"}, "lon": []string{"-0.09"}},
+		{"lat": []string{"91"}, "lon": []string{"-0.09"}},
+		{"lat": []string{"51.51"}, "lon": []string{"Inf"}},
+		{"lat": []string{"51.51", "51.52"}, "lon": []string{"-0.09"}},
+	}
+
+	for _, query := range errorCases {
+		t.Run(fmt.Sprint(query), func(t *testing.T) {
+			_, err := GetLocateParams(query)
+			assert.NotNil(t, err)
+		})
+	}
+}
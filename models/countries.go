@@ -0,0 +1,49 @@
+package models
+
+import (
+	"regexp"
+	"slices"
+)
+
+// GSS codes of the countries of the UK
+const (
+	CountryEngland         = "E92000001"
+	CountryWales           = "W92000004"
+	CountryScotland        = "S92000003"
+	CountryNorthernIreland = "N92000002"
+)
+
+// countryName is a word or phrase in a query that refers to one or more
+// countries
+type countryName struct {
+	re    *regexp.Regexp
+	codes []string
+}
+
+// countryNames are matched against the whole query, so "England and Wales"
+// is found as England and Wales. GB and NI must be upper case so that they
+// are not confused with ordinary words.
+var countryNames = []countryName{
+	{re: regexp.MustCompile(`(?i)\b(?:united kingdom|uk)\b`), codes: []string{CountryEngland, CountryWales, CountryScotland, CountryNorthernIreland}},
+	{re: regexp.MustCompile(`\b(?i:(?:great )?britain)\b|\bGB\b`), codes: []string{CountryEngland, CountryWales, CountryScotland}},
+	{re: regexp.MustCompile(`(?i)\b(?:england|english)\b`), codes: []string{CountryEngland}},
+	{re: regexp.MustCompile(`(?i)\b(?:wales|welsh|cymru)\b`), codes: []string{CountryWales}},
+	{re: regexp.MustCompile(`(?i)\b(?:scotland|scottish|scots)\b`), codes: []string{CountryScotland}},
+	{re: regexp.MustCompile(`\b(?i:northern (?:ireland|irish))\b|\bNI\b`), codes: []string{CountryNorthernIreland}},
+}
+
+// findCountriesInQuery sets the countries named in the query. The words are
+// left in the query, as they often describe the data searched for as well.
+func (sp *ScrubberParams) findCountriesInQuery() {
+	for _, name := range countryNames {
+		if !name.re.MatchString(sp.Query) {
+			continue
+		}
+
+		for _, code := range name.codes {
+			if !slices.Contains(sp.Countries, code) {
+				sp.Countries = append(sp.Countries, code)
+			}
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/text"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindCountriesInQuery(t *testing.T) {
+	tests := []struct {
+		q        string
+		expected []string
+	}{
+		{q: "bakers in Wales", expected: []string{CountryWales}},
+		{q: "Scottish fishing", expected: []string{CountryScotland}},
+		{q: "population of England and Wales", expected: []string{CountryEngland, CountryWales}},
+		{q: "UK unemployment", expected: []string{CountryEngland, CountryWales, CountryScotland, CountryNorthernIreland}},
+		{q: "great britain and Northern Ireland", expected: []string{CountryEngland, CountryWales, CountryScotland, CountryNorthernIreland}},
+		{q: "NI farms", expected: []string{CountryNorthernIreland}},
+		{q: "ni hao", expected: nil},
+		{q: "newales bakery", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.q, func(t *testing.T) {
+			params, err := GetScrubberParams(url.Values{"q": []string{tt.q}}, "", text.DefaultStopWords(), testMatchers)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, params.Countries)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package models
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/geo"
+)
+
+// gridRefRe matches an Ordnance Survey grid reference e.g. TQ 3080 8090,
+// TQ30808090 or TQ3080. Only references of 6 digits or more may be written
+// with a space after the letters, which must then be upper case, so that
+// "SO 2021" and "no 2021" keep their years.
+var gridRefRe = regexp.MustCompile(`\b(?:([HJNOST][A-HJ-Z])\s(\d{5}\s?\d{5}|\d{4}\s?\d{4}|\d{3}\s?\d{3})|([HJNOST][A-HJ-Z]|(?i:[hjnost][a-hj-z]))(\d{5}\s\d{5}|\d{4}\s\d{4}|\d{3}\s\d{3}|\d{10}|\d{8}|\d{6}|\d{4}))\b`)
+
+// eastingNorthingRe matches a British National Grid location written as
+// "easting,northing" or "easting northing" in metres e.g. 530800,180900
+var eastingNorthingRe = regexp.MustCompile(`\b(\d{6})(?:\s*,\s*|\s+)(\d{6,7})\b`)
+
+// the extent of the British National Grid in metres
+const (
+	maxEasting  = 700000
+	maxNorthing = 1300000
+)
+
+// splitGridRefsFromQuery moves any grid references or eastings and northings
+// out of the query, adding the location of each to the coordinates
+func (sp *ScrubberParams) splitGridRefsFromQuery() {
+	sp.Query = gridRefRe.ReplaceAllStringFunc(sp.Query, func(match string) string {
+		m := gridRefRe.FindStringSubmatch(match)
+
+		// only one of the spaced and unspaced forms is matched
+		easting, northing, ok := parseGridRef(m[1]+m[3], m[2]+m[4])
+		if !ok {
+			return match
+		}
+
+		sp.Coordinates = append(sp.Coordinates, osgbToWGS84(easting, northing))
+
+		return " "
+	})
+
+	sp.Query = eastingNorthingRe.ReplaceAllStringFunc(sp.Query, func(match string) string {
+		m := eastingNorthingRe.FindStringSubmatch(match)
+
+		easting, _ := strconv.ParseFloat(m[1], 64)
+		northing, _ := strconv.ParseFloat(m[2], 64)
+
+		if easting > maxEasting || northing > maxNorthing {
+			return match
+		}
+
+		sp.Coordinates = append(sp.Coordinates, osgbToWGS84(easting, northing))
+
+		return " "
+	})
+}
+
+// parseGridRef returns the easting and northing of the centre of the square
+// given by a grid reference, whose precision depends on its number of digits
+func parseGridRef(letters, digits string) (easting, northing float64, ok bool) {
+	letters = strings.ToUpper(strings.TrimSpace(letters))
+	digits = strings.ReplaceAll(digits, " ", "")
+
+	// the letters of the 500km and 100km squares each index a 5x5 grid of
+	// the alphabet, which leaves out I
+	l1, l2 := int(letters[0]-'A'), int(letters[1]-'A')
+	if l1 > 7 {
+		l1--
+	}
+
+	if l2 > 7 {
+		l2--
+	}
+
+	e100km := ((l1-2)%5+5)%5*5 + l2%5
+	n100km := 19 - l1/5*5 - l2/5
+
+	if e100km*100000 >= maxEasting || n100km*100000 >= maxNorthing {
+		return 0, 0, false
+	}
+
+	half := len(digits) / 2
+	e, _ := strconv.Atoi(digits[:half])
+	n, _ := strconv.Atoi(digits[half:])
+
+	size := math.Pow10(5 - half)
+
+	easting = float64(e100km*100000) + float64(e)*size + size/2
+	northing = float64(n100km*100000) + float64(n)*size + size/2
+
+	return easting, northing, true
+}
+
+// osgbToWGS84 converts an easting and northing on the British National Grid
+// to a WGS84 coordinate
+func osgbToWGS84(easting, northing float64) Coordinate {
+	lat, lon := geo.OSGBToWGS84(easting, northing)
+
+	return Coordinate{Latitude: lat, Longitude: lon}
+}
@@ -0,0 +1,119 @@
+package models
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/text"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGridRef(t *testing.T) {
+	tests := []struct {
+		letters, digits   string
+		easting, northing float64
+	}{
+		{letters: "TQ", digits: "3080 8090", easting: 530805, northing: 180905},
+		{letters: "TQ", digits: "30808090", easting: 530805, northing: 180905},
+		{letters: "tq", digits: "3080", easting: 530500, northing: 180500},
+		{letters: "TG", digits: "51409 13177", easting: 651409.5, northing: 313177.5},
+		{letters: "NN", digits: "16 71", easting: 216500, northing: 771500},
+		{letters: "HP", digits: "40 12", easting: 440500, northing: 1212500},
+		{letters: "SV", digits: "9000 1000", easting: 90005, northing: 10005},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.letters+tt.digits, func(t *testing.T) {
+			easting, northing, ok := parseGridRef(tt.letters, tt.digits)
+			assert.True(t, ok)
+			assert.Equal(t, tt.easting, easting)
+			assert.Equal(t, tt.northing, northing)
+		})
+	}
+
+	// HA is north of the grid
+	_, _, ok := parseGridRef("HA", "1234")
+	assert.False(t, ok)
+}
+
+func TestSplitGridRefsFromQuery(t *testing.T) {
+	tests := []struct {
+		name          string
+		q             string
+		expectedQuery string
+		expected      []Coordinate
+	}{
+		{
+			name:          "spaced grid reference",
+			q:             "planning TQ 3080 8090",
+			expectedQuery: "planning",
+			expected:      []Coordinate{{Latitude: 51.5119, Longitude: -0.1164}},
+		},
+		{
+			name:          "lower case grid reference",
+			q:             "flooding tq3080",
+			expectedQuery: "flooding",
+			expected:      []Coordinate{{Latitude: 51.5084, Longitude: -0.1210}},
+		},
+		{
+			name:          "eastings and northings",
+			q:             "dentists 530800,180900",
+			expectedQuery: "dentists",
+			expected:      []Coordinate{{Latitude: 51.5119, Longitude: -0.1165}},
+		},
+		{
+			name:          "lower case letters apart from the digits are words",
+			q:             "so 2020 bakers",
+			expectedQuery: "2020 bakers",
+		},
+		{
+			name:          "spaced grid reference of 6 digits",
+			q:             "planning TQ 308 809",
+			expectedQuery: "planning",
+			expected:      []Coordinate{{Latitude: 51.5123, Longitude: -0.1158}},
+		},
+		{
+			name:          "a year after letters is not a grid reference",
+			q:             "SO 2021 data",
+			expectedQuery: "2021 data",
+		},
+		{
+			name:          "a year after no is not a grid reference",
+			q:             "NO 2021",
+			expectedQuery: "2021",
+		},
+		{
+			name:          "eastings and northings off the grid",
+			q:             "dentists 950000 180900",
+			expectedQuery: "dentists 950000 180900",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := GetScrubberParams(url.Values{"q": []string{tt.q}}, "", text.DefaultStopWords(), testMatchers)
+			assert.Nil(t, err)
+
+			assert.Equal(t, tt.expectedQuery, params.Query)
+
+			if assert.Len(t, params.Coordinates, len(tt.expected)) {
+				for i, c := range tt.expected {
+					assert.InDelta(t, c.Latitude, params.Coordinates[i].Latitude, 0.0001)
+					assert.InDelta(t, c.Longitude, params.Coordinates[i].Longitude, 0.0001)
+				}
+			}
+		})
+	}
+}
+
+func TestYearAfterGridLetters(t *testing.T) {
+	for _, q := range []string{"SO 2021 data", "NO 2021"} {
+		t.Run(q, func(t *testing.T) {
+			params, err := GetScrubberParams(url.Values{"q": []string{q}}, "", text.DefaultStopWords(), append(testMatchers, PeriodMatcher{}))
+			assert.Nil(t, err)
+
+			assert.Empty(t, params.Coordinates)
+			assert.Equal(t, map[string][]string{CodePeriod: {"2021"}}, params.Codes)
+		})
+	}
+}
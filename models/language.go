@@ -0,0 +1,53 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/text"
+	"golang.org/x/text/language"
+)
+
+// Languages a response can be given in
+const (
+	LanguageEnglish = text.English
+	LanguageWelsh   = text.Welsh
+)
+
+var validLanguages = []string{LanguageEnglish, LanguageWelsh}
+
+// languageMatcher picks the supported language closest to those a client
+// accepts, preferring English when none are close
+var languageMatcher = language.NewMatcher([]language.Tag{language.English, language.MustParse(LanguageWelsh)})
+
+// GetLanguage returns the language of the response, given by the lang
+// parameter or otherwise by the Accept-Language header, defaulting to English
+func GetLanguage(query url.Values, acceptLanguage string) (string, error) {
+	if query.Has("lang") {
+		if len(query["lang"]) > 1 {
+			return "", fmt.Errorf("one lang expected, found multiple")
+		}
+
+		lang := strings.ToLower(query.Get("lang"))
+
+		if !slices.Contains(validLanguages, lang) {
+			return "", fmt.Errorf("invalid lang %q, expected one of %s", lang, strings.Join(validLanguages, ", "))
+		}
+
+		return lang, nil
+	}
+
+	// a malformed header is ignored rather than failing the request
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return LanguageEnglish, nil
+	}
+
+	if _, i, confidence := languageMatcher.Match(tags...); confidence != language.No {
+		return validLanguages[i], nil
+	}
+
+	return LanguageEnglish, nil
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLanguage(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          url.Values
+		acceptLanguage string
+		expected       string
+	}{
+		{name: "default", expected: LanguageEnglish},
+		{name: "lang parameter", query: url.Values{"lang": []string{"cy"}}, expected: LanguageWelsh},
+		{name: "lang parameter in upper case", query: url.Values{"lang": []string{"CY"}}, expected: LanguageWelsh},
+		{name: "lang parameter over the header", query: url.Values{"lang": []string{"en"}}, acceptLanguage: "cy", expected: LanguageEnglish},
+		{name: "header", acceptLanguage: "cy-GB,cy;q=0.9,en;q=0.8", expected: LanguageWelsh},
+		{name: "header preferring English", acceptLanguage: "en-GB,en;q=0.9,cy;q=0.8", expected: LanguageEnglish},
+		{name: "header with no supported language", acceptLanguage: "fr-FR", expected: LanguageEnglish},
+		{name: "malformed header", acceptLanguage: ";;q=x", expected: LanguageEnglish},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lang, err := GetLanguage(tt.query, tt.acceptLanguage)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, lang)
+		})
+	}
+}
+
+func TestGetLanguageReturnsError(t *testing.T) {
+	_, err := GetLanguage(url.Values{"lang": []string{"fr"}}, "")
+	assert.Equal(t, fmt.Errorf("invalid lang \"fr\", expected one of en, cy"), err)
+
+	_, err = GetLanguage(url.Values{"lang": []string{"en", "cy"}}, "")
+	assert.Equal(t, fmt.Errorf("one lang expected, found multiple"), err)
+}
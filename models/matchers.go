@@ -0,0 +1,99 @@
+package models
+
+import "strings"
+
+// Names of the kinds of code the scrubber has always recognised, which key
+// them in ScrubberParams.Codes
+const (
+	CodeSIC        = "sic"
+	CodeOutputArea = "oa"
+)
+
+// Matcher recognises the tokens of a query that are codes of one kind. The
+// codes it finds are taken out of the query and kept under its name in
+// ScrubberParams.Codes.
+type Matcher interface {
+	// Name is the kind of code matched
+	Name() string
+	// Match returns the code that token is, in its normal form, and whether
+	// it is one
+	Match(token string) (string, bool)
+}
+
+// SpanMatcher is a Matcher that also recognises codes written over several
+// words, such as "March 2023", which are taken out of the query before it is
+// split into tokens
+type SpanMatcher interface {
+	Matcher
+	// MatchSpans returns the codes in query, in their normal form, and query
+	// without them
+	MatchSpans(query string) (codes []string, rest string)
+}
+
+// SICMatcher matches SIC codes e.g. 12345
+type SICMatcher struct{}
+
+// Name returns CodeSIC
+func (SICMatcher) Name() string {
+	return CodeSIC
+}
+
+// Match reports whether token is a SIC code
+func (SICMatcher) Match(token string) (string, bool) {
+	return token, sicCodeRe.MatchString(token)
+}
+
+// OutputAreaMatcher matches output area codes e.g. E12345678, which also
+// matches LSOA (E01...) and MSOA (E02...) codes, returning them in upper case
+type OutputAreaMatcher struct{}
+
+// Name returns CodeOutputArea
+func (OutputAreaMatcher) Name() string {
+	return CodeOutputArea
+}
+
+// Match reports whether token is an output area code
+func (OutputAreaMatcher) Match(token string) (string, bool) {
+	return strings.ToUpper(token), oacCodeRe.MatchString(token)
+}
+
+// matchCode returns the name of the matcher that token is most likely a code
+// of, and the code it found
+func matchCode(matchers []Matcher, token string) (name, code string, ok bool) {
+	if candidates := matchCandidates(matchers, token); len(candidates) > 0 {
+		return candidates[0].Kind, candidates[0].Code, true
+	}
+
+	return "", "", false
+}
+
+// splitSpansFromQuery moves the codes written over several words out of the
+// query, for each of matchers that recognises them
+func (sp *ScrubberParams) splitSpansFromQuery(matchers []Matcher) {
+	for _, matcher := range matchers {
+		spanMatcher, ok := matcher.(SpanMatcher)
+		if !ok {
+			continue
+		}
+
+		var codes []string
+
+		codes, sp.Query = spanMatcher.MatchSpans(sp.Query)
+
+		for _, code := range codes {
+			sp.Codes = addCode(sp.Codes, matcher.Name(), code)
+		}
+	}
+}
+
+// addCode adds code to the codes of the named kind, unless it is already
+// there
+func addCode(codes map[string][]string, name, code string) map[string][]string {
+	if codes == nil {
+		codes = make(map[string][]string)
+	}
+
+	codes[name] = appendUnique(codes[name], code)
+
+	return codes
+}
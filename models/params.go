@@ -4,23 +4,107 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/text"
+)
+
+// Values of the group_by parameter, setting how matching output areas are
+// rolled up in the results
+const (
+	GroupByNone       = "none"
+	GroupByLA         = "la"
+	GroupByRegion     = "region"
+	GroupByCountry    = "country"
+	GroupBySupergroup = "supergroup"
+)
+
+var validGroupBy = []string{GroupByNone, GroupByLA, GroupByRegion, GroupByCountry, GroupBySupergroup}
+
+// Values of the crosswalk parameter, naming the classifications that SIC
+// 2007 industries are given the equivalent codes of
+const (
+	CrosswalkNACE    = "nace"
+	CrosswalkISIC    = "isic"
+	CrosswalkSIC2003 = "sic2003"
 )
 
+var validCrosswalks = []string{CrosswalkNACE, CrosswalkISIC, CrosswalkSIC2003}
+
+// options are the query parameters that may be given alongside q
+var options = []string{"ambiguity", "crosswalk", "group_by", "lang"}
+
+// sicSectionRe matches the letter of a SIC section, such as C for
+// manufacturing, which is only kept when it follows "section"
+var sicSectionRe = regexp.MustCompile(`^[A-Ua-u]$`)
+
+// sicCodeRe matches how a SIC code looks e.g. 12345
+var sicCodeRe = regexp.MustCompile(`^\d{5}$`)
+
+// oacCodeRe matches how an output area code looks e.g. E12345678, which also
+// matches LSOA (E01...) and MSOA (E02...) codes
+var oacCodeRe = regexp.MustCompile(`^[a-zA-Z]\d{8}$`)
+
+// coordinatesRe matches a location written as "latitude,longitude" e.g. 51.51,-0.09
+var coordinatesRe = regexp.MustCompile(`(?:^|[^\d.\-])(-?\d{1,2}\.\d+)\s*,\s*(-?\d{1,3}\.\d+)`)
+
 type ScrubberParams struct {
-	Query string
-	SIC   []string
-	OAC   []string
+	Query    string
+	GroupBy  string
+	Language string
+	// Crosswalk are the classifications whose equivalent codes are given
+	// for each industry in the results
+	Crosswalk   []string
+	Coordinates []Coordinate
+	Countries   []string
+	// Codes are the codes found in the query, keyed by the name of the
+	// matcher that recognised them
+	Codes map[string][]string
+	// Phrases are the phrases of the query given in double quotes, which are
+	// matched to names as a whole
+	Phrases []string
+	// Excluded are the words and phrases of the query marked with a minus
+	// sign, and ExcludedCodes the codes, keyed as Codes are, to be left out
+	// of the results
+	Excluded      []string
+	ExcludedCodes map[string][]string
+	// Terms are the normalised words left in the query once codes and
+	// locations are taken out, for matching against names
+	Terms []string
+	// Ambiguity is the policy for ambiguous tokens given by the query, if
+	// any, and Ambiguities the tokens matched by more than one matcher, whose
+	// codes are added to Codes by ResolveAmbiguities
+	Ambiguity   string
+	Ambiguities []AmbiguityResp
+}
+
+// Coordinate is a location given by its latitude and longitude in degrees
+type Coordinate struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Valid reports whether c is a location on the earth
+func (c Coordinate) Valid() bool {
+	return c.Latitude >= -90 && c.Latitude <= 90 && c.Longitude >= -180 && c.Longitude <= 180
 }
 
-func GetScrubberParams(query url.Values) (*ScrubberParams, error) {
-	result := ScrubberParams{
-		Query: "",
-		SIC:   []string{},
-		OAC:   []string{},
+// GetScrubberParams parses the query parameters of a search, taking the codes
+// recognised by matchers and the locations out of q and leaving out the
+// stopWords of its language. A token matched by more than one of matchers is
+// kept in Ambiguities, and its codes are only added once ResolveAmbiguities
+// is called. The language is given by the lang parameter or the
+// acceptLanguage header.
+func GetScrubberParams(query url.Values, acceptLanguage string, stopWords text.StopWords, matchers []Matcher) (*ScrubberParams, error) {
+	result := ScrubberParams{}
+
+	if err := result.setOptions(query, acceptLanguage); err != nil {
+		return nil, err
 	}
 
-	if len(query) != 1 {
+	if len(query)-countOptions(query) != 1 {
 		return nil, fmt.Errorf("one query expected, found multiple queries ")
 	}
 
@@ -34,48 +118,158 @@ func GetScrubberParams(query url.Values) (*ScrubberParams, error) {
 
 	result.Query = query["q"][0]
 
+	result.splitExclusionsFromQuery(matchers)
+
+	result.findCountriesInQuery()
+
+	result.splitPhrasesFromQuery()
+
+	result.splitCoordinatesFromQuery()
+
+	result.splitGridRefsFromQuery()
+
+	result.splitSpansFromQuery(matchers)
+
 	result.rmSpecialCharsFromQuery()
 
-	result.splitAllAcceptableCodesFromQuery()
+	protected := result.splitAllAcceptableCodesFromQuery(matchers, stopWords)
+
+	result.Terms = queryTerms(result.Query, protected, stopWords)
+
+	result.addPhrasesToQuery()
 
 	return &result, nil
 }
 
-func (sp *ScrubberParams) rmSpecialCharsFromQuery() {
-	re := regexp.MustCompile("[^A-Za-z0-9]+")
+func countOptions(query url.Values) int {
+	count := 0
+
+	for _, name := range options {
+		if query.Has(name) {
+			count++
+		}
+	}
+
+	return count
+}
+
+func (sp *ScrubberParams) setOptions(query url.Values, acceptLanguage string) error {
+	for _, name := range options {
+		if len(query[name]) > 1 {
+			return fmt.Errorf("one %s expected, found multiple", name)
+		}
+	}
+
+	if query.Has("group_by") {
+		sp.GroupBy = query.Get("group_by")
+
+		if !slices.Contains(validGroupBy, sp.GroupBy) {
+			return fmt.Errorf("invalid group_by %q, expected one of %s", sp.GroupBy, strings.Join(validGroupBy, ", "))
+		}
+	}
+
+	if query.Has("ambiguity") {
+		sp.Ambiguity = query.Get("ambiguity")
+
+		if !ValidAmbiguity(sp.Ambiguity) {
+			return fmt.Errorf("invalid ambiguity %q, expected one of %s", sp.Ambiguity, strings.Join(validAmbiguities, ", "))
+		}
+	}
 
-	sp.Query = re.ReplaceAllString(sp.Query, " ")
+	if query.Has("crosswalk") {
+		for _, system := range strings.Split(query.Get("crosswalk"), ",") {
+			system = strings.ToLower(strings.TrimSpace(system))
+
+			if !slices.Contains(validCrosswalks, system) {
+				return fmt.Errorf("invalid crosswalk %q, expected one of %s", system, strings.Join(validCrosswalks, ", "))
+			}
+
+			sp.Crosswalk = appendUnique(sp.Crosswalk, system)
+		}
+	}
+
+	lang, err := GetLanguage(query, acceptLanguage)
+	if err != nil {
+		return err
+	}
+
+	sp.Language = lang
+
+	return nil
 }
 
-func (sp *ScrubberParams) splitAllAcceptableCodesFromQuery() {
+// splitCoordinatesFromQuery moves any locations written as "latitude,longitude"
+// out of the query, before their punctuation is removed. A pair outside the
+// range of latitude and longitude is taken out too, as it is no location and
+// its numbers are nothing to search for.
+func (sp *ScrubberParams) splitCoordinatesFromQuery() {
+	matches := coordinatesRe.FindAllStringSubmatchIndex(sp.Query, -1)
+
+	// work backwards so that removing a match leaves the earlier indexes valid
+	for i := len(matches) - 1; i >= 0; i-- {
+		m := matches[i]
+
+		lat, latErr := strconv.ParseFloat(sp.Query[m[2]:m[3]], 64)
+		lon, lonErr := strconv.ParseFloat(sp.Query[m[4]:m[5]], 64)
+
+		c := Coordinate{Latitude: lat, Longitude: lon}
+		if latErr == nil && lonErr == nil && c.Valid() {
+			sp.Coordinates = append([]Coordinate{c}, sp.Coordinates...)
+		}
+
+		sp.Query = sp.Query[:m[2]] + " " + sp.Query[m[5]:]
+	}
+}
+
+// rmSpecialCharsFromQuery replaces punctuation with spaces, keeping letters
+// in every script along with their accents, so "Ynys Môn" and "café" are
+// returned as they were written
+func (sp *ScrubberParams) rmSpecialCharsFromQuery() {
+	sp.Query = strings.Join(strings.FieldsFunc(text.Normalise(sp.Query), func(r rune) bool {
+		return !text.IsWordRune(r)
+	}), " ")
+}
+
+// splitAllAcceptableCodesFromQuery moves the codes recognised by matchers out
+// of the query, and leaves out repeated words and stop words other than
+// protected ones, which are returned. A token matched by more than one
+// matcher is kept in Ambiguities with a candidate for each.
+func (sp *ScrubberParams) splitAllAcceptableCodesFromQuery(matchers []Matcher, stopWords text.StopWords) (protected map[string]bool) {
 	querySl := strings.Split(sp.Query, " ")
 	sp.Query = ""
 
-	// regex for how a sic code looks like e.g. 12345
-	sicCodeRe := regexp.MustCompile(`^\d{5}$`)
-
-	// regex for how a output area code looks like e.g. E12345678
-	oacCodeRe := regexp.MustCompile(`^[a-zA-Z]\d{8}$`)
+	protected = make(map[string]bool)
 
 	// cache is here to make sure we don't duplicate entries
 	cache := make(map[string]string)
+	previous := ""
+
 	for _, v := range querySl {
-		// if it matches a SIC code
-		if _, ok := cache[v]; !ok && sicCodeRe.MatchString(v) {
-			cache[v] = v
-			sp.SIC = append(sp.SIC, v)
+		if isProtected(v, previous, sp.Language, stopWords) {
+			protected[v] = true
+		}
+
+		previous = v
+
+		if _, ok := cache[v]; ok {
 			continue
 		}
 
-		// if it matches a OAC code
-		if _, ok := cache[v]; !ok && oacCodeRe.MatchString(v) {
+		// if it matches a code, or more than one
+		if candidates := matchCandidates(matchers, v); len(candidates) > 0 {
 			cache[v] = v
-			sp.OAC = append(sp.OAC, v)
+
+			if len(candidates) == 1 {
+				sp.Codes = addCode(sp.Codes, candidates[0].Kind, candidates[0].Code)
+			} else {
+				sp.Ambiguities = append(sp.Ambiguities, AmbiguityResp{Term: v, Candidates: candidates})
+			}
+
 			continue
 		}
 
-		// if it doesn't match a OAC or SIC code and isn't composed of 2 letters
-		if _, ok := cache[v]; !ok && len(v) > 2 {
+		// if it doesn't match a code and isn't a stop word
+		if protected[v] || isSearchable(v, sp.Language, stopWords) {
 			cache[v] = v
 
 			// first sp.Query is always empty
@@ -87,4 +281,36 @@ func (sp *ScrubberParams) splitAllAcceptableCodesFromQuery() {
 			sp.Query = sp.Query + " " + v
 		}
 	}
+
+	return protected
+}
+
+// isProtected reports whether word is kept in the query even if it is a stop
+// word: an abbreviation that stopWords keeps for language, or the letter of a
+// SIC section after "section"
+func isProtected(word, previous, language string, stopWords text.StopWords) bool {
+	return stopWords.Keeps(language, word) || sicSectionRe.MatchString(word) && strings.EqualFold(previous, "section")
+}
+
+// isSearchable reports whether a word left in the query is worth searching
+// for
+func isSearchable(word, language string, stopWords text.StopWords) bool {
+	return word != "" && !stopWords.Contains(language, word)
+}
+
+// queryTerms returns the terms of query for matching against names, leaving
+// out stop words but keeping the protected words
+func queryTerms(query string, protected map[string]bool, stopWords text.StopWords) []string {
+	var terms []string
+
+	for _, word := range strings.Fields(query) {
+		if protected[word] {
+			terms = append(terms, text.Stem(strings.ToLower(word)))
+			continue
+		}
+
+		terms = append(terms, stopWords.Terms(word)...)
+	}
+
+	return terms
 }
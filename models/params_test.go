@@ -5,9 +5,14 @@ import (
 	"net/url"
 	"testing"
 
+	"github.com/ONSdigital/dp-search-scrubber-api/text"
 	"github.com/stretchr/testify/assert"
 )
 
+// testMatchers are the matchers the search registers for SIC and output area
+// codes
+var testMatchers = []Matcher{SICMatcher{}, OutputAreaMatcher{}}
+
 func TestGetScrubberParamsHappyCases(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -20,9 +25,9 @@ func TestGetScrubberParamsHappyCases(t *testing.T) {
 				"q": []string{"dentists"},
 			},
 			expected: &ScrubberParams{
-				Query: "dentists",
-				SIC:   []string{},
-				OAC:   []string{},
+				Query:    "dentists",
+				Terms:    []string{"dentist"},
+				Language: LanguageEnglish,
 			},
 		},
 		{
@@ -31,9 +36,20 @@ func TestGetScrubberParamsHappyCases(t *testing.T) {
 				"q": []string{"1 dental-care!"},
 			},
 			expected: &ScrubberParams{
-				Query: "dental care",
-				SIC:   []string{},
-				OAC:   []string{},
+				Query:    "1 dental care",
+				Terms:    []string{"1", "dental", "care"},
+				Language: LanguageEnglish,
+			},
+		},
+		{
+			name: "query with a number",
+			query: url.Values{
+				"q": []string{"top 10 baby names"},
+			},
+			expected: &ScrubberParams{
+				Query:    "top 10 baby names",
+				Terms:    []string{"top", "10", "baby", "name"},
+				Language: LanguageEnglish,
 			},
 		},
 		{
@@ -42,9 +58,10 @@ func TestGetScrubberParamsHappyCases(t *testing.T) {
 				"q": []string{"12345 dentists"},
 			},
 			expected: &ScrubberParams{
-				Query: "dentists",
-				SIC:   []string{"12345"},
-				OAC:   []string{},
+				Query:    "dentists",
+				Terms:    []string{"dentist"},
+				Codes:    map[string][]string{CodeSIC: {"12345"}},
+				Language: LanguageEnglish,
 			},
 		},
 		{
@@ -53,9 +70,121 @@ func TestGetScrubberParamsHappyCases(t *testing.T) {
 				"q": []string{"X12345678 dentists"},
 			},
 			expected: &ScrubberParams{
-				Query: "dentists",
-				SIC:   []string{},
-				OAC:   []string{"X12345678"},
+				Query:    "dentists",
+				Terms:    []string{"dentist"},
+				Codes:    map[string][]string{CodeOutputArea: {"X12345678"}},
+				Language: LanguageEnglish,
+			},
+		},
+		{
+			name: "query grouped by region",
+			query: url.Values{
+				"q":        []string{"X12345678 dentists"},
+				"group_by": []string{"region"},
+			},
+			expected: &ScrubberParams{
+				Query:    "dentists",
+				Terms:    []string{"dentist"},
+				Codes:    map[string][]string{CodeOutputArea: {"X12345678"}},
+				Language: LanguageEnglish,
+				GroupBy:  GroupByRegion,
+			},
+		},
+		{
+			name: "query with crosswalks",
+			query: url.Values{
+				"q":         []string{"12345 dentists"},
+				"crosswalk": []string{"NACE, isic,nace"},
+			},
+			expected: &ScrubberParams{
+				Query:     "dentists",
+				Terms:     []string{"dentist"},
+				Codes:     map[string][]string{CodeSIC: {"12345"}},
+				Language:  LanguageEnglish,
+				Crosswalk: []string{CrosswalkNACE, CrosswalkISIC},
+			},
+		},
+		{
+			name: "query with coordinates",
+			query: url.Values{
+				"q": []string{"dentists near 51.51,-0.09 and 52.2, 0.12"},
+			},
+			expected: &ScrubberParams{
+				Query:    "dentists",
+				Terms:    []string{"dentist"},
+				Language: LanguageEnglish,
+				Coordinates: []Coordinate{
+					{Latitude: 51.51, Longitude: -0.09},
+					{Latitude: 52.2, Longitude: 0.12},
+				},
+			},
+		},
+		{
+			name: "query with coordinates out of range",
+			query: url.Values{
+				"q": []string{"dentists 95.1,0.1"},
+			},
+			expected: &ScrubberParams{
+				Query:    "dentists",
+				Terms:    []string{"dentist"},
+				Language: LanguageEnglish,
+			},
+		},
+		{
+			name: "query with stop words and protected short words",
+			query: url.Values{
+				"q": []string{"the IT consultants in NI and the UK, section C"},
+			},
+			expected: &ScrubberParams{
+				Query:     "IT consultants NI UK section C",
+				Terms:     []string{"it", "consultant", "ni", "uk", "section", "c"},
+				Language:  LanguageEnglish,
+				Countries: []string{CountryEngland, CountryWales, CountryScotland, CountryNorthernIreland},
+			},
+		},
+		{
+			name: "single capital letters are stop words",
+			query: url.Values{
+				"q": []string{"I need A dentist"},
+			},
+			expected: &ScrubberParams{
+				Query:    "need dentist",
+				Terms:    []string{"need", "dentist"},
+				Language: LanguageEnglish,
+			},
+		},
+		{
+			name: "a section letter that is a stop word",
+			query: url.Values{
+				"q": []string{"IT in section A agriculture"},
+			},
+			expected: &ScrubberParams{
+				Query:    "IT section A agriculture",
+				Terms:    []string{"it", "section", "a", "agricultur"},
+				Language: LanguageEnglish,
+			},
+		},
+		{
+			name: "query with accents",
+			query: url.Values{
+				"q": []string{"café near Ynys Mo\u0302n!"},
+			},
+			expected: &ScrubberParams{
+				Query:    "café Ynys Môn",
+				Terms:    []string{"cafe", "yny", "mon"},
+				Language: LanguageEnglish,
+			},
+		},
+		{
+			name: "query in Welsh",
+			query: url.Values{
+				"q":    []string{"deintyddion yng Nghaerdydd"},
+				"lang": []string{"cy"},
+			},
+			expected: &ScrubberParams{
+				Query:    "deintyddion Nghaerdydd",
+				Terms:    []string{"deintyddion", "nghaerdydd"},
+				Language: LanguageWelsh,
 			},
 		},
 		{
@@ -64,22 +193,35 @@ func TestGetScrubberParamsHappyCases(t *testing.T) {
 				"q": []string{"12345 X12345678 dentists 12345 X12345678"},
 			},
 			expected: &ScrubberParams{
-				Query: "dentists",
-				SIC:   []string{"12345"},
-				OAC:   []string{"X12345678"},
+				Query:    "dentists",
+				Terms:    []string{"dentist"},
+				Codes:    map[string][]string{CodeSIC: {"12345"}, CodeOutputArea: {"X12345678"}},
+				Language: LanguageEnglish,
 			},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			params, err := GetScrubberParams(tt.query)
+			params, err := GetScrubberParams(tt.query, "", text.DefaultStopWords(), testMatchers)
 			assert.Empty(t, err)
 			assert.Equal(t, tt.expected, params)
 		})
 	}
 }
 
+func TestGetScrubberParamsConfiguredAbbreviations(t *testing.T) {
+	query := url.Values{"q": []string{"IT jobs in the UK"}}
+
+	// only the abbreviations in the stop words are kept
+	stopWords := text.NewStopWords([][2]string{{"en", "it"}, {"en", "in"}, {"en", "the"}, {"en", "uk"}, {"en", "UK"}})
+
+	params, err := GetScrubberParams(query, "", stopWords, testMatchers)
+	assert.Nil(t, err)
+	assert.Equal(t, "jobs UK", params.Query)
+	assert.Equal(t, []string{"job", "uk"}, params.Terms)
+}
+
 func TestGetScrubberParamsReturnsError(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -109,10 +251,57 @@ func TestGetScrubberParamsReturnsError(t *testing.T) {
 			},
 			expected: fmt.Errorf("one query expected, found multiple queries "),
 		},
+		{
+			name: "invalid group_by",
+			query: url.Values{
+				"q":        []string{"12345 dentists"},
+				"group_by": []string{"street"},
+			},
+			expected: fmt.Errorf("invalid group_by \"street\", expected one of none, la, region, country, supergroup"),
+		},
+		{
+			name: "multiple group_by",
+			query: url.Values{
+				"q":        []string{"12345 dentists"},
+				"group_by": []string{"la", "region"},
+			},
+			expected: fmt.Errorf("one group_by expected, found multiple"),
+		},
+		{
+			name: "invalid ambiguity",
+			query: url.Values{
+				"q":         []string{"12345 dentists"},
+				"ambiguity": []string{"random"},
+			},
+			expected: fmt.Errorf("invalid ambiguity \"random\", expected one of first, all, none"),
+		},
+		{
+			name: "invalid crosswalk",
+			query: url.Values{
+				"q":         []string{"12345 dentists"},
+				"crosswalk": []string{"nace,naics"},
+			},
+			expected: fmt.Errorf("invalid crosswalk \"naics\", expected one of nace, isic, sic2003"),
+		},
+		{
+			name: "invalid lang",
+			query: url.Values{
+				"q":    []string{"12345 dentists"},
+				"lang": []string{"fr"},
+			},
+			expected: fmt.Errorf("invalid lang \"fr\", expected one of en, cy"),
+		},
+		{
+			name: "group_by without a query",
+			query: url.Values{
+				"group_by": []string{"la"},
+			},
+			expected: fmt.Errorf("one query expected, found multiple queries "),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			params, err := GetScrubberParams(tt.query)
+			params, err := GetScrubberParams(tt.query, "", text.DefaultStopWords(), testMatchers)
 			assert.Empty(t, params)
 			assert.Equal(t, tt.expected, err)
 		})
@@ -1,24 +1,240 @@
 package models
 
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
 type ScrubberResp struct {
-	Time    string  `json:"time"`
-	Query   string  `json:"query"`
-	Results Results `json:"results,omitempty"`
+	Time  string `json:"time"`
+	Query string `json:"query"`
+	// RewrittenQuery is the query as written with the area and industry
+	// codes in it replaced by their names, and Rewrites mark where
+	RewrittenQuery string        `json:"rewritten_query"`
+	Rewrites       []RewriteResp `json:"rewrites,omitempty"`
+	Results        Results       `json:"results,omitempty"`
+}
+
+// RewriteResp is a span of the rewritten query that replaced a code: Start
+// and End are character offsets into the rewritten query, and Kind is the
+// level of the area or "sic" for an industry
+type RewriteResp struct {
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+	Code  string `json:"code"`
+	Kind  string `json:"kind"`
+	Label string `json:"label"`
 }
 
 type Results struct {
+	Areas      []AreaResp      `json:"areas,omitempty"`
+	Industries []IndustryResp  `json:"industries,omitempty"`
+	Countries  []GeographyResp `json:"countries,omitempty"`
+	Excluded   *ExcludedResp   `json:"excluded,omitempty"`
+	// Ambiguities are the tokens and names of the query that could mean
+	// more than one thing
+	Ambiguities []AmbiguityResp `json:"ambiguities,omitempty"`
+	// Entities are the results of kinds other than those above, keyed by
+	// the name of their section, which are found by the recognisers
+	// registered with the search
+	Entities map[string]any `json:"-"`
+}
+
+// resultsFields are the sections of Results that have their own fields, by
+// the names of their JSON tags
+var resultsFields = jsonFieldNames(reflect.TypeOf(Results{}))
+
+// IsReservedSection reports whether name is that of a section of the results
+// with its own field, such as "areas", which the entities may not replace
+func IsReservedSection(name string) bool {
+	return slices.Contains(resultsFields, name)
+}
+
+// jsonFieldNames returns the names that the fields of the struct type t are
+// written with in JSON
+func jsonFieldNames(t reflect.Type) []string {
+	var names []string
+
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// Add sets the section of the results called name to values. name must not
+// be a reserved section, which the recognisers' sections are checked against
+// when they are registered and which MarshalJSON refuses.
+func (r *Results) Add(name string, values any) {
+	if r.Entities == nil {
+		r.Entities = make(map[string]any)
+	}
+
+	r.Entities[name] = values
+}
+
+// MarshalJSON writes the entities alongside the other sections of the results
+func (r Results) MarshalJSON() ([]byte, error) {
+	type results Results
+
+	b, err := json.Marshal(results(r))
+	if err != nil || len(r.Entities) == 0 {
+		return b, err
+	}
+
+	var sections map[string]json.RawMessage
+	if err := json.Unmarshal(b, &sections); err != nil {
+		return nil, err
+	}
+
+	for name, values := range r.Entities {
+		if IsReservedSection(name) {
+			return nil, fmt.Errorf("results section %q is reserved", name)
+		}
+
+		if sections[name], err = json.Marshal(values); err != nil {
+			return nil, err
+		}
+	}
+
+	return json.Marshal(sections)
+}
+
+// UnmarshalJSON reads the sections of the results that have no field of their
+// own into Entities, undecoded
+func (r *Results) UnmarshalJSON(b []byte) error {
+	type results Results
+
+	if err := json.Unmarshal(b, (*results)(r)); err != nil {
+		return err
+	}
+
+	var sections map[string]json.RawMessage
+	if err := json.Unmarshal(b, &sections); err != nil {
+		return err
+	}
+
+	for _, field := range resultsFields {
+		delete(sections, field)
+	}
+
+	for name, section := range sections {
+		r.Add(name, section)
+	}
+
+	return nil
+}
+
+// ExcludedResp is what the query asked to leave out of the results: the
+// words, phrases and codes marked with a minus sign, and the areas and
+// industries they name
+type ExcludedResp struct {
+	Terms      []string       `json:"terms,omitempty"`
 	Areas      []AreaResp     `json:"areas,omitempty"`
 	Industries []IndustryResp `json:"industries,omitempty"`
 }
 
 type AreaResp struct {
-	Name       string            `json:"name,omitempty"`
-	Region     string            `json:"region,omitempty"`
-	RegionCode string            `json:"region_code,omitempty"`
-	Codes      map[string]string `json:"codes,omitempty"`
+	Level       string            `json:"level,omitempty"`
+	Code        string            `json:"code,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Region      string            `json:"region,omitempty"`
+	RegionCode  string            `json:"region_code,omitempty"`
+	Country     string            `json:"country,omitempty"`
+	CountryCode string            `json:"country_code,omitempty"`
+	Codes       map[string]string `json:"codes,omitempty"`
+	Count       int               `json:"count,omitempty"`
+	Hierarchy   []GeographyResp   `json:"hierarchy,omitempty"`
+	// MappedFrom are the retired codes in the query that the area was found
+	// by, through their successors
+	MappedFrom []GeographyResp `json:"mapped_from,omitempty"`
+	// Ambiguous is set when the name in the query the area was found by is
+	// shared with other areas, which are given as candidates alongside it
+	Ambiguous bool `json:"ambiguous,omitempty"`
+}
+
+type GeographyResp struct {
+	Level string `json:"level,omitempty"`
+	Code  string `json:"code,omitempty"`
+	Name  string `json:"name,omitempty"`
 }
 
 type IndustryResp struct {
-	Code string `json:"code,omitempty"`
-	Name string `json:"name,omitempty"`
+	Code    string `json:"code,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Synonym string `json:"synonym,omitempty"`
+	// MappedFrom is the code of another classification in the query that
+	// the industry was found by
+	MappedFrom *ClassificationResp `json:"mapped_from,omitempty"`
+	// Crosswalk are the industry's equivalent codes in the classifications
+	// asked for
+	Crosswalk []ClassificationResp `json:"crosswalk,omitempty"`
+}
+
+// ClassificationResp is a code of an industrial classification other than
+// SIC 2007, such as NACE Rev.2
+type ClassificationResp struct {
+	System string `json:"system"`
+	Code   string `json:"code"`
+	Name   string `json:"name,omitempty"`
+}
+
+// AmbiguityResp is a token or name of the query that could mean more than
+// one thing, with a candidate for each meaning, best first
+type AmbiguityResp struct {
+	Term       string          `json:"term"`
+	Candidates []CandidateResp `json:"candidates"`
+}
+
+// CandidateResp is one meaning of an ambiguous term: the kind of code or
+// level of area, its code and how likely it is, from 0 to 1. Selected
+// candidates are the ones that went into the results.
+type CandidateResp struct {
+	Kind     string  `json:"kind"`
+	Code     string  `json:"code"`
+	Name     string  `json:"name,omitempty"`
+	Score    float64 `json:"score"`
+	Selected bool    `json:"selected"`
+}
+
+// PeriodResp is a period of time found in the query, in ISO 8601 style, with
+// its first and last days
+type PeriodResp struct {
+	Period string `json:"period"`
+	Start  string `json:"start"`
+	End    string `json:"end"`
+}
+
+// TimeSeriesResp is an ONS time series whose CDID is in the query
+type TimeSeriesResp struct {
+	CDID    string `json:"cdid"`
+	Title   string `json:"title,omitempty"`
+	Dataset string `json:"dataset,omitempty"`
+}
+
+// CensusResp is a Census 2021 table or variable whose code is in the query
+type CensusResp struct {
+	Code  string `json:"code"`
+	Title string `json:"title,omitempty"`
+	Type  string `json:"type"`
+}
+
+// OccupationResp is a group of the Standard Occupational Classification 2020
+// found in the query, with the groups it belongs to from smallest to largest
+type OccupationResp struct {
+	Level     string           `json:"level"`
+	Code      string           `json:"code"`
+	Title     string           `json:"title,omitempty"`
+	Hierarchy []OccupationResp `json:"hierarchy,omitempty"`
+}
+
+type AreasResp struct {
+	Time  string     `json:"time"`
+	Areas []AreaResp `json:"areas"`
 }
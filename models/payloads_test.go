@@ -0,0 +1,52 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultsJSON(t *testing.T) {
+	results := Results{
+		Industries: []IndustryResp{{Code: "01120", Name: "Growing of rice"}},
+	}
+	results.Add("periods", []string{"2021"})
+
+	b, err := json.Marshal(results)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"industries":[{"code":"01120","name":"Growing of rice"}],"periods":["2021"]}`, string(b))
+
+	var decoded Results
+	assert.Nil(t, json.Unmarshal(b, &decoded))
+
+	assert.Equal(t, results.Industries, decoded.Industries)
+	assert.Equal(t, map[string]any{"periods": json.RawMessage(`["2021"]`)}, decoded.Entities)
+
+	t.Run("without entities", func(t *testing.T) {
+		b, err := json.Marshal(Results{})
+		assert.Nil(t, err)
+		assert.Equal(t, `{}`, string(b))
+
+		var decoded Results
+		assert.Nil(t, json.Unmarshal(b, &decoded))
+		assert.Nil(t, decoded.Entities)
+	})
+}
+
+func TestResultsReservedSections(t *testing.T) {
+	for _, name := range []string{"areas", "industries", "countries", "excluded", "ambiguities"} {
+		t.Run(name, func(t *testing.T) {
+			assert.True(t, IsReservedSection(name))
+
+			var results Results
+			results.Add(name, []string{"2021"})
+
+			_, err := json.Marshal(results)
+			assert.NotNil(t, err)
+		})
+	}
+
+	assert.False(t, IsReservedSection("periods"))
+	assert.False(t, IsReservedSection("Entities"))
+}
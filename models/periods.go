@@ -0,0 +1,218 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CodePeriod is the name of the kind of code for periods of time, which key
+// them in ScrubberParams.Codes
+const CodePeriod = "period"
+
+// monthNames are the names of the months in English, in full and shortened,
+// and in Welsh
+var monthNames = map[string]time.Month{
+	"january": time.January, "jan": time.January, "ionawr": time.January,
+	"february": time.February, "feb": time.February, "chwefror": time.February,
+	"march": time.March, "mar": time.March, "mawrth": time.March,
+	"april": time.April, "apr": time.April, "ebrill": time.April,
+	"may": time.May, "mai": time.May,
+	"june": time.June, "jun": time.June, "mehefin": time.June,
+	"july": time.July, "jul": time.July, "gorffennaf": time.July,
+	"august": time.August, "aug": time.August, "awst": time.August,
+	"september": time.September, "sep": time.September, "sept": time.September, "medi": time.September,
+	"october": time.October, "oct": time.October, "hydref": time.October,
+	"november": time.November, "nov": time.November, "tachwedd": time.November,
+	"december": time.December, "dec": time.December, "rhagfyr": time.December,
+}
+
+// yearPattern matches the years that are taken to be periods rather than
+// other numbers, from 1900 to 2099
+const yearPattern = `(?:19|20)\d{2}`
+
+// periodPattern matches a year, a quarter of a year e.g. Q3 2022 or 2022 Q3,
+// or a month of a year e.g. March 2023
+var periodPattern = fmt.Sprintf(`(?:q[1-4]\s*%[1]s|%[1]s\s*q[1-4]|(?:%[2]s)\s+%[1]s|%[1]s)`, yearPattern, monthPattern())
+
+// periodSpanRe matches a period or a range of periods e.g. 2019 to 2021 in a
+// query. Years next to a decimal point are left alone, as they are part of a
+// number.
+var periodSpanRe = regexp.MustCompile(`(?i)\b(` + periodPattern + `)(?:\s*(?:to|until|i|-|–)\s*(` + periodPattern + `))?\b`)
+
+var (
+	yearRe     = regexp.MustCompile(`^(` + yearPattern + `)$`)
+	quarterRe  = regexp.MustCompile(`(?i)^(?:q([1-4])\s*(` + yearPattern + `)|(` + yearPattern + `)\s*q([1-4]))$`)
+	monthRe    = regexp.MustCompile(`(?i)^(` + monthPattern() + `)\s+(` + yearPattern + `)$`)
+	periodCode = regexp.MustCompile(`^(` + yearPattern + `)(?:-(Q[1-4]|\d{2}))?$`)
+)
+
+// monthPattern matches the name of a month, trying longer names first so
+// that "march" is not matched as "mar"
+func monthPattern() string {
+	names := make([]string, 0, len(monthNames))
+	for name := range monthNames {
+		names = append(names, name)
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		if len(names[i]) != len(names[j]) {
+			return len(names[i]) > len(names[j])
+		}
+
+		return names[i] < names[j]
+	})
+
+	return strings.Join(names, "|")
+}
+
+// PeriodMatcher matches periods of time, returning them in ISO 8601 style: a
+// year as 2021, a quarter as 2022-Q3, a month as 2023-03, and a range of
+// them as 2019/2021
+type PeriodMatcher struct{}
+
+// Name returns CodePeriod
+func (PeriodMatcher) Name() string {
+	return CodePeriod
+}
+
+// Match reports whether token is a period written as one word, such as a
+// year or 2022Q3
+func (PeriodMatcher) Match(token string) (string, bool) {
+	return parsePeriod(token)
+}
+
+// Score returns how likely token is to be a period. A bare year is less
+// certain than a quarter or month, as four digit numbers are also other kinds
+// of code.
+func (PeriodMatcher) Score(token string) float64 {
+	if yearRe.MatchString(token) {
+		return 0.8
+	}
+
+	return 1
+}
+
+// MatchSpans returns the periods and ranges of periods in query, which may be
+// written over several words, and query without them. Years on their own are
+// left to Match.
+func (PeriodMatcher) MatchSpans(query string) (codes []string, rest string) {
+	matches := periodSpanRe.FindAllStringSubmatchIndex(query, -1)
+
+	rest = query
+
+	// work backwards so that removing a match leaves the earlier indexes valid
+	for i := len(matches) - 1; i >= 0; i-- {
+		m := matches[i]
+
+		if m[0] > 0 && query[m[0]-1] == '.' || m[1] < len(query) && query[m[1]] == '.' && m[1]+1 < len(query) && isDigit(query[m[1]+1]) {
+			continue
+		}
+
+		code, ok := parsePeriod(query[m[2]:m[3]])
+		if !ok {
+			continue
+		}
+
+		if m[4] >= 0 {
+			code, ok = periodRange(code, query[m[4]:m[5]])
+			if !ok {
+				continue
+			}
+		} else if yearRe.MatchString(code) {
+			// a year on its own is one token, and is left to be weighed
+			// against the other kinds of code a four digit number can be
+			continue
+		}
+
+		codes = append([]string{code}, codes...)
+		rest = rest[:m[0]] + " " + rest[m[1]:]
+	}
+
+	return codes, rest
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// parsePeriod returns the code of a single period written as a year, a
+// quarter or a month
+func parsePeriod(s string) (string, bool) {
+	if yearRe.MatchString(s) {
+		return s, true
+	}
+
+	if m := quarterRe.FindStringSubmatch(s); m != nil {
+		if m[1] != "" {
+			return m[2] + "-Q" + m[1], true
+		}
+
+		return m[3] + "-Q" + m[4], true
+	}
+
+	if m := monthRe.FindStringSubmatch(s); m != nil {
+		return fmt.Sprintf("%s-%02d", m[2], monthNames[strings.ToLower(m[1])]), true
+	}
+
+	return "", false
+}
+
+// periodRange returns the code of the range from the period start to the
+// period written as end, as long as end does not come before it
+func periodRange(start, end string) (string, bool) {
+	endCode, ok := parsePeriod(end)
+	if !ok {
+		return "", false
+	}
+
+	from, _, _ := PeriodDates(start)
+	_, to, _ := PeriodDates(endCode)
+
+	if to.Before(from) {
+		return "", false
+	}
+
+	return start + "/" + endCode, true
+}
+
+// PeriodDates returns the first and last days of the period with code, which
+// may be a range of periods
+func PeriodDates(code string) (start, end time.Time, ok bool) {
+	if from, to, isRange := strings.Cut(code, "/"); isRange {
+		start, _, okFrom := PeriodDates(from)
+		_, end, okTo := PeriodDates(to)
+
+		return start, end, okFrom && okTo
+	}
+
+	m := periodCode.FindStringSubmatch(code)
+	if m == nil {
+		return time.Time{}, time.Time{}, false
+	}
+
+	year, _ := strconv.Atoi(m[1])
+
+	switch {
+	case m[2] == "":
+		start = time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		end = start.AddDate(1, 0, -1)
+	case m[2][0] == 'Q':
+		quarter := int(m[2][1] - '0')
+		start = time.Date(year, time.Month(3*quarter-2), 1, 0, 0, 0, 0, time.UTC)
+		end = start.AddDate(0, 3, -1)
+	default:
+		month, _ := strconv.Atoi(m[2])
+		if month < 1 || month > 12 {
+			return time.Time{}, time.Time{}, false
+		}
+
+		start = time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+		end = start.AddDate(0, 1, -1)
+	}
+
+	return start, end, true
+}
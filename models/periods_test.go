@@ -0,0 +1,92 @@
+package models
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/text"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeriodsInQuery(t *testing.T) {
+	matchers := append([]Matcher{PeriodMatcher{}}, testMatchers...)
+
+	tests := []struct {
+		name            string
+		q               string
+		expectedQuery   string
+		expectedPeriods []string
+	}{
+		{
+			name:            "year",
+			q:               "dentists in 2021",
+			expectedQuery:   "dentists",
+			expectedPeriods: []string{"2021"},
+		},
+		{
+			name:            "quarters",
+			q:               "GDP Q3 2022 and 2023 q1",
+			expectedQuery:   "GDP",
+			expectedPeriods: []string{"2022-Q3", "2023-Q1"},
+		},
+		{
+			name:            "months",
+			q:               "inflation March 2023, sept 2022 and Mawrth 2024",
+			expectedQuery:   "inflation",
+			expectedPeriods: []string{"2023-03", "2022-09", "2024-03"},
+		},
+		{
+			name:            "ranges",
+			q:               "population 2019 to 2021, Q1 2020-Q2 2021",
+			expectedQuery:   "population",
+			expectedPeriods: []string{"2019/2021", "2020-Q1/2021-Q2"},
+		},
+		{
+			name:            "backwards range",
+			q:               "population 2021 to 2019",
+			expectedQuery:   "population",
+			expectedPeriods: []string{"2021", "2019"},
+		},
+		{
+			name:          "numbers that are not years",
+			q:             "1850 workers 51.2021,-0.09 in 12345",
+			expectedQuery: "1850 workers",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := GetScrubberParams(url.Values{"q": []string{tt.q}}, "", text.DefaultStopWords(), matchers)
+			assert.Nil(t, err)
+
+			assert.Equal(t, tt.expectedQuery, params.Query)
+			assert.Equal(t, tt.expectedPeriods, params.Codes[CodePeriod])
+		})
+	}
+}
+
+func TestPeriodDates(t *testing.T) {
+	tests := []struct {
+		code  string
+		start string
+		end   string
+	}{
+		{code: "2021", start: "2021-01-01", end: "2021-12-31"},
+		{code: "2022-Q3", start: "2022-07-01", end: "2022-09-30"},
+		{code: "2024-02", start: "2024-02-01", end: "2024-02-29"},
+		{code: "2019/2021-Q1", start: "2019-01-01", end: "2021-03-31"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			start, end, ok := PeriodDates(tt.code)
+			assert.True(t, ok)
+			assert.Equal(t, tt.start, start.Format(time.DateOnly))
+			assert.Equal(t, tt.end, end.Format(time.DateOnly))
+		})
+	}
+
+	_, _, ok := PeriodDates("2021-13")
+	assert.False(t, ok)
+}
@@ -0,0 +1,117 @@
+package models
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/text"
+)
+
+// exclusionRe matches a word or quoted phrase marked with a minus sign to
+// leave it out of the results, such as -wholesale or -"retail of bread". The
+// minus sign must start a word, so that it is not taken from a hyphenated
+// word.
+var exclusionRe = regexp.MustCompile(`(?:^|\s)-(?:"([^"]*)"|([^\s"]+))`)
+
+// phraseRe matches a phrase in double quotes, which is matched to names as a
+// whole rather than word by word
+var phraseRe = regexp.MustCompile(`"([^"]*)"`)
+
+// splitExclusionsFromQuery moves the words and phrases marked with a minus
+// sign out of the query. The codes recognised by matchers are kept apart from
+// the words, and other negative numbers such as the longitude -0.09 are left
+// alone.
+func (sp *ScrubberParams) splitExclusionsFromQuery(matchers []Matcher) {
+	matches := exclusionRe.FindAllStringSubmatchIndex(sp.Query, -1)
+
+	var excluded []string
+
+	query := sp.Query
+
+	// work backwards so that removing a match leaves the earlier indexes valid
+	for i := len(matches) - 1; i >= 0; i-- {
+		m := matches[i]
+
+		var value string
+
+		if m[2] >= 0 {
+			value = sp.Query[m[2]:m[3]]
+		} else {
+			value = sp.Query[m[4]:m[5]]
+
+			if _, err := strconv.ParseFloat(value, 64); err == nil {
+				if _, _, ok := matchCode(matchers, value); !ok {
+					continue
+				}
+			}
+		}
+
+		query = query[:m[0]] + " " + query[m[1]:]
+
+		if value = cleanPhrase(value); value != "" {
+			excluded = append([]string{value}, excluded...)
+		}
+	}
+
+	sp.Query = query
+
+	for _, value := range excluded {
+		if name, code, ok := matchCode(matchers, value); ok {
+			sp.ExcludedCodes = addCode(sp.ExcludedCodes, name, code)
+		} else {
+			sp.Excluded = appendUnique(sp.Excluded, value)
+		}
+	}
+}
+
+// splitPhrasesFromQuery moves the phrases in double quotes out of the query,
+// so that their words are neither matched nor left out one by one
+func (sp *ScrubberParams) splitPhrasesFromQuery() {
+	for _, m := range phraseRe.FindAllStringSubmatch(sp.Query, -1) {
+		if phrase := cleanPhrase(m[1]); phrase != "" {
+			sp.Phrases = appendUnique(sp.Phrases, phrase)
+		}
+	}
+
+	// an unmatched quote is dropped along with the rest of the punctuation
+	sp.Query = phraseRe.ReplaceAllString(sp.Query, " ")
+}
+
+// addPhrasesToQuery puts the phrases back at the start of the query, in
+// quotes, for the full text search that the query is passed on to
+func (sp *ScrubberParams) addPhrasesToQuery() {
+	if len(sp.Phrases) == 0 {
+		return
+	}
+
+	quoted := make([]string, 0, len(sp.Phrases)+1)
+
+	for _, phrase := range sp.Phrases {
+		quoted = append(quoted, `"`+phrase+`"`)
+	}
+
+	if sp.Query != "" {
+		quoted = append(quoted, sp.Query)
+	}
+
+	sp.Query = strings.Join(quoted, " ")
+}
+
+// cleanPhrase removes the punctuation from a phrase, keeping its words in
+// order
+func cleanPhrase(phrase string) string {
+	return strings.Join(strings.FieldsFunc(text.Normalise(phrase), func(r rune) bool {
+		return !text.IsWordRune(r)
+	}), " ")
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+
+	return append(values, value)
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/ONSdigital/dp-search-scrubber-api/text"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhrasesAndExclusions(t *testing.T) {
+	tests := []struct {
+		name             string
+		q                string
+		expectedQuery    string
+		expectedTerms    []string
+		expectedPhrases  []string
+		expectedExcluded []string
+		expectedCodes    map[string][]string
+	}{
+		{
+			name:            "quoted phrase",
+			q:               `"retail of bread" in london`,
+			expectedQuery:   `"retail of bread" london`,
+			expectedTerms:   []string{"london"},
+			expectedPhrases: []string{"retail of bread"},
+		},
+		{
+			name:             "excluded word",
+			q:                `"retail of bread" -wholesale`,
+			expectedQuery:    `"retail of bread"`,
+			expectedPhrases:  []string{"retail of bread"},
+			expectedExcluded: []string{"wholesale"},
+		},
+		{
+			name:             "excluded phrase",
+			q:                `bakers -"City of London", london`,
+			expectedQuery:    "bakers london",
+			expectedTerms:    []string{"bake", "london"},
+			expectedExcluded: []string{"City of London"},
+		},
+		{
+			name:          "excluded codes",
+			q:             "-01120 bakers -e00000001 -E00000001",
+			expectedQuery: "bakers",
+			expectedTerms: []string{"bake"},
+			expectedCodes: map[string][]string{CodeSIC: {"01120"}, CodeOutputArea: {"E00000001"}},
+		},
+		{
+			name:          "hyphenated words and negative longitudes are kept",
+			q:             "tea-rooms 51.51, -0.09",
+			expectedQuery: "tea rooms",
+			expectedTerms: []string{"tea", "room"},
+		},
+		{
+			name:          "unmatched quote",
+			q:             `"bakers london`,
+			expectedQuery: "bakers london",
+			expectedTerms: []string{"bake", "london"},
+		},
+		{
+			name:            "repeated words in a phrase are kept",
+			q:               `"bread and bread" bread`,
+			expectedQuery:   `"bread and bread" bread`,
+			expectedTerms:   []string{"bread"},
+			expectedPhrases: []string{"bread and bread"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := GetScrubberParams(url.Values{"q": []string{tt.q}}, "", text.DefaultStopWords(), testMatchers)
+			assert.Nil(t, err)
+
+			assert.Equal(t, tt.expectedQuery, params.Query)
+			assert.Equal(t, tt.expectedTerms, params.Terms)
+			assert.Equal(t, tt.expectedPhrases, params.Phrases)
+			assert.Equal(t, tt.expectedExcluded, params.Excluded)
+			assert.Equal(t, tt.expectedCodes, params.ExcludedCodes)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package sdk
 import (
 	"net/http"
 	"net/url"
+	"strings"
 )
 
 const (
@@ -31,6 +32,26 @@ func (o *Options) Q(val string) *Options {
 	return o
 }
 
+// GroupBy sets the 'group_by' Query parameter to the request
+func (o *Options) GroupBy(val string) *Options {
+	o.Query.Set("group_by", val)
+	return o
+}
+
+// Ambiguity sets the 'ambiguity' Query parameter to the request, choosing
+// which candidates for an ambiguous term go into the results
+func (o *Options) Ambiguity(val string) *Options {
+	o.Query.Set("ambiguity", val)
+	return o
+}
+
+// Crosswalk sets the 'crosswalk' Query parameter to the request, naming the
+// classifications to give the equivalent codes of industries in
+func (o *Options) Crosswalk(systems ...string) *Options {
+	o.Query.Set("crosswalk", strings.Join(systems, ","))
+	return o
+}
+
 func setHeaders(req *http.Request, headers http.Header) {
 	for name, values := range headers {
 		for _, value := range values {
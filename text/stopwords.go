@@ -0,0 +1,123 @@
+package text
+
+import (
+	"strings"
+	"unicode"
+)
+
+// English is the language of queries unless another is asked for
+const English = "en"
+
+// StopWords are the words of each language, keyed by language code, that are
+// too common to say anything about what is being searched for. They are held
+// in lower case, alongside the abbreviations kept in upper case.
+type StopWords map[string]map[string]bool
+
+// NewStopWords returns the stop words of each language from pairs of
+// language code and word. A word of more than one letter given in capitals is
+// not a stop word but an abbreviation that is kept when written in capitals,
+// such as "IT", so that "IT jobs" keeps "IT" while "it" is still left out.
+func NewStopWords(pairs [][2]string) StopWords {
+	sw := make(StopWords)
+
+	for _, pair := range pairs {
+		language, word := strings.ToLower(strings.TrimSpace(pair[0])), strings.TrimSpace(pair[1])
+		if language == "" || word == "" {
+			continue
+		}
+
+		if !isAbbreviation(word) {
+			word = strings.ToLower(word)
+		}
+
+		if sw[language] == nil {
+			sw[language] = make(map[string]bool)
+		}
+
+		sw[language][word] = true
+	}
+
+	return sw
+}
+
+// DefaultStopWords returns the English and Welsh stop words used when no
+// list is configured
+func DefaultStopWords() StopWords {
+	var pairs [][2]string
+
+	for language, words := range map[string]string{English: defaultEnglishStopWords, Welsh: defaultWelshStopWords} {
+		for _, word := range strings.Fields(words) {
+			pairs = append(pairs, [2]string{language, word})
+		}
+	}
+
+	return NewStopWords(pairs)
+}
+
+const defaultEnglishStopWords = `
+	a about all also an and any are as at be been but by can do does except for
+	from had has have how i if in into is it its me my near nearby no not of on
+	or other our s show so the their there these this those to was we were what
+	when where which who why will with within you your
+	EU GB IT NI UK
+`
+
+const defaultWelshStopWords = `
+	a ac ag am ar at beth ble dan dros drwy ei eu fy gan ger gyda hefyd i mae
+	mewn o oddi pa r wrth y yn yng ym yr
+`
+
+// Contains reports whether word is a stop word of language, in any case
+func (sw StopWords) Contains(language, word string) bool {
+	return sw[language][strings.ToLower(word)]
+}
+
+// Keeps reports whether word, as written, is an abbreviation of language
+// kept in a query even if it is also a stop word
+func (sw StopWords) Keeps(language, word string) bool {
+	return isAbbreviation(word) && sw[language][word]
+}
+
+// isAbbreviation reports whether word is more than one letter, all of them
+// capitals
+func isAbbreviation(word string) bool {
+	letters := 0
+
+	for _, r := range word {
+		if !unicode.IsUpper(r) {
+			return false
+		}
+
+		letters++
+	}
+
+	return letters > 1
+}
+
+// Terms splits s into the normalised terms used for matching, leaving out
+// punctuation and the stop words of every language, as names in either
+// language are matched against queries in both. Accents are folded, so
+// "Ynys Mon" matches "Ynys Môn".
+func (sw StopWords) Terms(s string) []string {
+	var terms []string
+
+	for _, word := range Words(Fold(s)) {
+		if !sw.containsAny(word) {
+			terms = append(terms, Stem(word))
+		}
+	}
+
+	return terms
+}
+
+// containsAny reports whether the lower case word is a stop word of any
+// language
+func (sw StopWords) containsAny(word string) bool {
+	for _, words := range sw {
+		if words[word] {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,194 @@
+// Package text normalises the words of queries and of the names they are
+// matched against, so that "bakers", "bakery" and "baking" are all the same
+// term. Everything here is deterministic and needs no external data.
+package text
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// irregular maps the plurals that suffix rules cannot fold to their singular
+var irregular = map[string]string{
+	"children": "child",
+	"feet":     "foot",
+	"geese":    "goose",
+	"men":      "man",
+	"mice":     "mouse",
+	"people":   "person",
+	"teeth":    "tooth",
+	"women":    "woman",
+}
+
+// minStem is the fewest letters a suffix rule may leave behind, so that short
+// words such as "ring" and "red" are not cut down to nothing
+const minStem = 3
+
+// Words splits s into lower case words of letters and digits in any script,
+// dropping punctuation. Accents are kept.
+func Words(s string) []string {
+	return strings.FieldsFunc(Normalise(strings.ToLower(s)), func(r rune) bool {
+		return !IsWordRune(r)
+	})
+}
+
+// IsWordRune reports whether r is part of a word, as a letter, digit or the
+// accent of a letter
+func IsWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r)
+}
+
+// Normalise composes each letter and its accents into a single character
+// where there is one, so that the same text is always written the same way
+func Normalise(s string) string {
+	return norm.NFC.String(s)
+}
+
+// Fold removes the accents from the letters of s, so "café" becomes "cafe"
+// and "Môn" becomes "Mon"
+func Fold(s string) string {
+	var b strings.Builder
+
+	for _, r := range norm.NFD.String(s) {
+		if !unicode.Is(unicode.Mn, r) {
+			b.WriteRune(r)
+		}
+	}
+
+	return norm.NFC.String(b.String())
+}
+
+// Stem reduces a lower case word to its stem by folding plurals and then
+// removing the endings of agent nouns, places and participles, so that
+// "manufacturers", "manufacturing" and "manufacture" all become
+// "manufactur", and "bakeries", "bakers", "baking" and "bake" all become
+// "bake"
+func Stem(word string) string {
+	if singular, ok := irregular[word]; ok {
+		return singular
+	}
+
+	// the endings are English, so words with other letters are left whole
+	if !isASCII(word) {
+		return word
+	}
+
+	word = foldPlural(word)
+
+	// endings can be stacked, as in "engineering"
+	for trimmed := true; trimmed; {
+		trimmed = false
+
+		for _, suffix := range []string{"ery", "er", "ing", "ed"} {
+			if stem, ok := trimSuffix(word, suffix); ok {
+				word, trimmed = restoreE(stem), true
+				break
+			}
+		}
+	}
+
+	// the silent e of a long word is dropped, so "manufacture" meets
+	// "manufacturing", but kept on a short one so "care" is not "car"
+	if stem, ok := trimSuffix(word, "e"); ok && !isShort(stem) {
+		word = stem
+	}
+
+	return word
+}
+
+func isASCII(word string) bool {
+	for i := 0; i < len(word); i++ {
+		if word[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+
+	return true
+}
+
+// restoreE tidies the stem left by removing an ending, either by removing
+// the consonant doubled before it, so "shipping" becomes "ship", or by
+// putting back the e it replaced, so "baking" becomes "bake"
+func restoreE(stem string) string {
+	n := len(stem)
+
+	if n >= 2 && stem[n-1] == stem[n-2] && !isVowel(stem, n-1) && strings.IndexByte("lsz", stem[n-1]) < 0 {
+		return stem[:n-1]
+	}
+
+	if isShort(stem) {
+		return stem + "e"
+	}
+
+	return stem
+}
+
+// isShort reports whether stem is a single syllable ending in a consonant,
+// vowel and consonant, such as "bak" or "car", but not "grow"
+func isShort(stem string) bool {
+	n := len(stem)
+	if n < 3 || strings.IndexByte("wxy", stem[n-1]) >= 0 {
+		return false
+	}
+
+	if isVowel(stem, n-1) || !isVowel(stem, n-2) || isVowel(stem, n-3) {
+		return false
+	}
+
+	// no other vowels before the last one
+	for i := 0; i < n-3; i++ {
+		if isVowel(stem, i) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isVowel reports whether the letter at i is a vowel, counting y as one
+// when it follows a consonant
+func isVowel(word string, i int) bool {
+	switch word[i] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return true
+	case 'y':
+		return i > 0 && !isVowel(word, i-1)
+	default:
+		return false
+	}
+}
+
+// foldPlural returns the singular of a regular plural, leaving words such as
+// "census", "analysis" and "business" alone
+func foldPlural(word string) string {
+	switch {
+	case strings.HasSuffix(word, "ies"):
+		if stem, ok := trimSuffix(word, "ies"); ok {
+			return stem + "y"
+		}
+	case strings.HasSuffix(word, "sses"):
+		return strings.TrimSuffix(word, "es")
+	case strings.HasSuffix(word, "ss"), strings.HasSuffix(word, "us"), strings.HasSuffix(word, "is"):
+		return word
+	case strings.HasSuffix(word, "s"):
+		if stem, ok := trimSuffix(word, "s"); ok {
+			return stem
+		}
+	}
+
+	return word
+}
+
+// trimSuffix removes suffix from word as long as what is left is long enough
+// to be a stem and has a vowel in it
+func trimSuffix(word, suffix string) (string, bool) {
+	stem, found := strings.CutSuffix(word, suffix)
+	if !found || utf8.RuneCountInString(stem) < minStem || !strings.ContainsAny(stem, "aeiouy") {
+		return word, false
+	}
+
+	return stem, true
+}
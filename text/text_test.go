@@ -0,0 +1,116 @@
+package text
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStem(t *testing.T) {
+	tests := []struct {
+		words    []string
+		expected string
+	}{
+		{words: []string{"bakers", "bakery", "baking", "bake", "bakeries", "Baker"}, expected: "bake"},
+		{words: []string{"engineer", "engineers", "engineering"}, expected: "engin"},
+		{words: []string{"manufacture", "manufacturer", "manufacturing", "manufactured"}, expected: "manufactur"},
+		{words: []string{"shipping", "ships"}, expected: "ship"},
+		{words: []string{"farming", "farmers", "farms"}, expected: "farm"},
+		{words: []string{"hairdressers", "hairdressing"}, expected: "hairdress"},
+		{words: []string{"activities", "activity"}, expected: "activity"},
+		{words: []string{"care", "caring"}, expected: "care"},
+		{words: []string{"car", "cars"}, expected: "car"},
+		{words: []string{"selling", "sell"}, expected: "sell"},
+		{words: []string{"census"}, expected: "census"},
+		{words: []string{"businesses", "business"}, expected: "business"},
+		{words: []string{"churches"}, expected: "church"},
+		{words: []string{"children"}, expected: "child"},
+		{words: []string{"ring"}, expected: "ring"},
+		{words: []string{"growing"}, expected: "grow"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			for _, word := range tt.words {
+				assert.Equal(t, tt.expected, Stem(Words(word)[0]), word)
+			}
+		})
+	}
+}
+
+func TestTerms(t *testing.T) {
+	sw := DefaultStopWords()
+
+	assert.Equal(t, []string{"dentist", "city", "london"}, sw.Terms("Dentists in the City of London"))
+	assert.Equal(t, []string{"orthodontist", "nhs"}, sw.Terms("Orthodontist (NHS)"))
+	assert.Nil(t, sw.Terms("of the"))
+	assert.Nil(t, sw.Terms(""))
+
+	// the welsh stop words are left out as well as the english ones
+	assert.Equal(t, []string{"deintydd", "nghaerdydd"}, sw.Terms("deintydd yng Nghaerdydd"))
+
+	// the stop words configured are the only ones left out
+	custom := NewStopWords([][2]string{{"en", "dentists"}})
+	assert.Equal(t, []string{"city", "of", "london"}, custom.Terms("Dentists City of London"))
+}
+
+func TestWords(t *testing.T) {
+	assert.Equal(t, []string{"café", "ynys", "môn"}, Words("Café, Ynys Môn!"))
+	// an accent written as a separate character is composed with its letter
+	assert.Equal(t, []string{"môn"}, Words("Mo\u0302n"))
+	assert.Equal(t, []string{"zürich", "łódź"}, Words("Zürich/Łódź"))
+}
+
+func TestFold(t *testing.T) {
+	assert.Equal(t, "Ynys Mon", Fold("Ynys Môn"))
+	assert.Equal(t, "cafe", Fold("café"))
+	assert.Equal(t, "Pen-y-bont ar Ogwr, Wrecsam, Bryn Wy", Fold("Pen-y-bont ar Ogwr, Wrecsam, Bryn Ŵy"))
+}
+
+func TestTermsFoldAccents(t *testing.T) {
+	sw := DefaultStopWords()
+	assert.Equal(t, sw.Terms("Ynys Mon cafes"), sw.Terms("Ynys Môn cafés"))
+}
+
+func TestStopWords(t *testing.T) {
+	sw := NewStopWords([][2]string{
+		{"en", "the"},
+		{" EN ", " Near "},
+		{"cy", "yng"},
+		{"", "orphan"},
+		{"en", ""},
+	})
+
+	assert.True(t, sw.Contains("en", "the"))
+	assert.True(t, sw.Contains("en", "NEAR"))
+	assert.True(t, sw.Contains("cy", "yng"))
+	assert.False(t, sw.Contains("en", "yng"))
+	assert.False(t, sw.Contains("cy", "the"))
+	assert.False(t, sw.Contains("", "orphan"))
+	assert.Len(t, sw["en"], 2)
+
+	var none StopWords
+	assert.False(t, none.Contains("en", "the"))
+
+	assert.True(t, DefaultStopWords().Contains(English, "and"))
+	assert.False(t, DefaultStopWords().Contains(English, "uk"))
+}
+
+func TestStopWordsKeeps(t *testing.T) {
+	sw := NewStopWords([][2]string{{"en", "it"}, {"en", "IT"}, {"en", "A"}})
+
+	// the abbreviation in capitals is kept, the word in lower case left out
+	assert.True(t, sw.Keeps("en", "IT"))
+	assert.False(t, sw.Keeps("en", "it"))
+	assert.False(t, sw.Keeps("en", "It"))
+	assert.False(t, sw.Keeps("cy", "IT"))
+	assert.True(t, sw.Contains("en", "it"))
+
+	// a single capital letter is a stop word like any other
+	assert.False(t, sw.Keeps("en", "A"))
+	assert.True(t, sw.Contains("en", "a"))
+
+	for _, abbreviation := range []string{"UK", "GB", "NI", "IT", "EU"} {
+		assert.True(t, DefaultStopWords().Keeps(English, abbreviation), abbreviation)
+	}
+}
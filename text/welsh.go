@@ -0,0 +1,45 @@
+package text
+
+import "strings"
+
+// Welsh is the language code of Welsh
+const Welsh = "cy"
+
+// mutation is the change to the first letters of a Welsh word after words
+// such as "yn" (in) and "ei" (his)
+type mutation map[string]string
+
+// mutations are the soft, nasal and aspirate mutations of Welsh, keyed by
+// the letters they replace. Longer prefixes are checked first, so "ll" is
+// not taken as "l".
+var mutations = []mutation{
+	{"ll": "l", "rh": "r", "p": "b", "t": "d", "c": "g", "b": "f", "d": "dd", "g": "", "m": "f"},
+	{"p": "mh", "t": "nh", "c": "ngh", "b": "m", "d": "n", "g": "ng"},
+	{"p": "ph", "t": "th", "c": "ch"},
+}
+
+// Mutations returns the lower case forms word takes after each of the Welsh
+// mutations that change it, so "Caerdydd" gives "gaerdydd", "nghaerdydd" and
+// "chaerdydd", as in "yng Nghaerdydd" (in Cardiff)
+func Mutations(word string) []string {
+	word = strings.ToLower(word)
+
+	var forms []string
+
+	for _, m := range mutations {
+		for _, prefix := range []string{"ll", "rh", "p", "t", "c", "b", "d", "g", "m"} {
+			replacement, ok := m[prefix]
+			if !ok || !strings.HasPrefix(word, prefix) {
+				continue
+			}
+
+			if form := replacement + word[len(prefix):]; form != "" {
+				forms = append(forms, form)
+			}
+
+			break
+		}
+	}
+
+	return forms
+}
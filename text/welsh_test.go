@@ -0,0 +1,29 @@
+package text
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMutations(t *testing.T) {
+	tests := []struct {
+		word     string
+		expected []string
+	}{
+		{word: "Caerdydd", expected: []string{"gaerdydd", "nghaerdydd", "chaerdydd"}},
+		{word: "Bangor", expected: []string{"fangor", "mangor"}},
+		{word: "Gwynedd", expected: []string{"wynedd", "ngwynedd"}},
+		{word: "Llanelli", expected: []string{"lanelli"}},
+		{word: "Pen-y-bont", expected: []string{"ben-y-bont", "mhen-y-bont", "phen-y-bont"}},
+		{word: "Rhondda", expected: []string{"rondda"}},
+		{word: "Ynys", expected: nil},
+		{word: "g", expected: []string{"ng"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Mutations(tt.word))
+		})
+	}
+}